@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,34 +10,53 @@ import (
 	"github.com/dev-araujo/chainlink-price-feed/internal/config"
 	"github.com/dev-araujo/chainlink-price-feed/internal/handler"
 	"github.com/dev-araujo/chainlink-price-feed/internal/service"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
 	cfg := config.Load()
-	if cfg.RpcURL == "" {
+	if cfg.RpcURLs[config.DefaultNetwork] == "" {
 		log.Fatal("RPC_URL não pode ser vazia.")
 	}
 	if cfg.ServerPort == "" {
 		cfg.ServerPort = "8080"
 	}
 
-	client, err := ethclient.Dial(cfg.RpcURL)
+	registry, err := config.LoadRegistry(cfg.FeedsFile)
 	if err != nil {
-		log.Fatalf("Falha ao conectar ao nó da rede principal da Ethereum: %v", err)
+		log.Fatalf("Falha ao carregar os feeds: %v", err)
 	}
-	log.Println("Conectado com sucesso à rede principal da Ethereum!")
 
-	exchangeService := service.NewExchangeService()
-	chainlinkService := service.NewChainlinkService(client, exchangeService)
-	assetService := service.NewAssetService()
+	exchangeService := service.NewCachedExchangeService(service.NewExchangeService(rateProviders(cfg)...), cfg.FXCacheTTL)
+	assetService := service.NewAssetService(registry.Logos())
 
-	priceHandler := handler.NewPriceHandler(chainlinkService, assetService)
+	networks := make(map[string]*service.Network)
+	for name, network := range config.Networks {
+		rpcURL, ok := cfg.RpcURLs[name]
+		if !ok {
+			continue
+		}
+		networks[name] = setupNetwork(cfg, network, rpcURL, registry.NetworkFeeds(name), exchangeService)
+	}
+
+	go func() {
+		err := config.WatchRegistry(context.Background(), cfg.FeedsFile, func(registry *config.Registry) {
+			assetService.SetImageURLs(registry.Logos())
+			for name, network := range networks {
+				network.SetFeeds(registry.NetworkFeeds(name))
+			}
+		})
+		if err != nil {
+			log.Printf("Aviso: recarga automática dos feeds desativada: %v", err)
+		}
+	}()
+
+	priceHandler := handler.NewPriceHandler(networks, assetService, exchangeService)
 
 	router := gin.Default()
 	router.Use(cors.Default())
+	router.Use(handler.RequestID())
 
 	priceHandler.RegisterRoutes(router)
 
@@ -59,3 +79,80 @@ func main() {
 		log.Fatalf("Falha ao iniciar o servidor: %v", err)
 	}
 }
+
+// rateProviders monta a cadeia de provedores de câmbio na ordem de
+// FX_PROVIDERS.
+func rateProviders(cfg *config.Config) []service.RateProvider {
+	var providers []service.RateProvider
+	for _, name := range cfg.FXProviders {
+		switch name {
+		case config.FXProviderFrankfurter:
+			providers = append(providers, service.NewFrankfurterProvider(""))
+		case config.FXProviderChainlink:
+			client, err := service.DialNetwork(context.Background(), config.Networks[config.DefaultNetwork], cfg.RpcURLs[config.DefaultNetwork])
+			if err != nil {
+				log.Fatalf("Falha ao conectar ao nó para o câmbio on-chain: %v", err)
+			}
+			providers = append(providers, service.NewChainlinkRateProvider(client, config.FiatFeeds))
+		case config.FXProviderPTAX:
+			providers = append(providers, service.NewPTAXProvider(""))
+		default:
+			log.Fatalf("Provedor de câmbio '%s' desconhecido em FX_PROVIDERS.", name)
+		}
+	}
+	if len(providers) == 0 {
+		log.Fatal("FX_PROVIDERS deve informar ao menos um provedor de câmbio.")
+	}
+	log.Printf("Provedores de câmbio, em ordem de prioridade: %v", cfg.FXProviders)
+	return providers
+}
+
+func setupNetwork(cfg *config.Config, network config.Network, rpcURL string, feeds map[string]config.Feed, exchangeService service.RateSource) *service.Network {
+	ctx := context.Background()
+
+	client, err := service.DialNetwork(ctx, network, rpcURL)
+	if err != nil {
+		log.Fatalf("Falha ao conectar ao nó: %v", err)
+	}
+	log.Printf("Conectado com sucesso à rede %s (chain ID %d)!", network.Name, network.ChainID)
+
+	chainlinkService := service.NewChainlinkService(client, feeds, exchangeService)
+	chainlinkService.LoadFeeds(ctx)
+
+	if cfg.FeedRegistry && network.Name == config.DefaultNetwork {
+		if err := chainlinkService.EnableFeedRegistry(); err != nil {
+			log.Printf("Aviso: Feed Registry desativado: %v", err)
+		} else {
+			log.Printf("Consulta ao Feed Registry ativada na rede %s", network.Name)
+		}
+	}
+
+	n := &service.Network{
+		Name:      network.Name,
+		ChainID:   network.ChainID,
+		Chainlink: chainlinkService,
+		Cache:     service.NewPriceCache(chainlinkService, cfg.CacheTTL),
+	}
+
+	if cfg.Poller {
+		n.Poller = service.NewPoller(chainlinkService)
+		go n.Poller.Run(ctx)
+		log.Printf("Atualização de preços em segundo plano ativada na rede %s", network.Name)
+	}
+
+	if wsURL, ok := cfg.WsRpcURLs[network.Name]; ok {
+		if n.Poller == nil {
+			log.Printf("Aviso: websocket da rede %s ignorado, a assinatura de eventos requer POLLER_ENABLED", network.Name)
+		} else {
+			wsClient, err := service.DialNetwork(ctx, network, wsURL)
+			if err != nil {
+				log.Fatalf("Falha ao conectar ao nó via websocket: %v", err)
+			}
+			n.Subscriber = service.NewSubscriber(wsClient, chainlinkService, n.Poller)
+			go n.Subscriber.Run(ctx)
+			log.Printf("Assinatura de eventos dos feeds ativada na rede %s", network.Name)
+		}
+	}
+
+	return n
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
 	"github.com/dev-araujo/chainlink-price-feed/internal/service"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
@@ -27,28 +28,33 @@ func main() {
 	}
 	defer client.Close()
 
-	exchangeService := service.NewExchangeService()
+	exchangeService := service.NewExchangeService(service.NewFrankfurterProvider(""), service.NewChainlinkRateProvider(client, config.FiatFeeds))
 
-	chainlinkService := service.NewChainlinkService(client, exchangeService)
+	registry, err := config.LoadRegistry(config.Load().FeedsFile)
+	if err != nil {
+		log.Fatalf("Falha ao carregar os feeds: %v", err)
+	}
+
+	chainlinkService := service.NewChainlinkService(client, registry.NetworkFeeds(config.DefaultNetwork), exchangeService)
 
 	asset := "xau" // eth| link| btc | aud | eur | jpy | ftse| xau |
 
 	fmt.Printf("Buscando preço para %s/USD...\n", asset)
-	priceDataUSD, err := chainlinkService.GetPriceUSD(context.Background(), asset)
+	priceDataUSD, err := chainlinkService.GetPriceUSD(context.Background(), asset, service.QueryOptions{})
 	if err != nil {
 		log.Fatalf("Erro ao buscar preço em USD: %v", err)
 	}
 	fmt.Printf("Par: %s\n", priceDataUSD.Pair)
-	fmt.Printf("Preço: %s\n", priceDataUSD.Price.String())
+	fmt.Printf("Preço: %s\n", priceDataUSD.Price.FloatString(8))
 	fmt.Printf("Última atualização (Timestamp): %d\n", priceDataUSD.Timestamp)
 	fmt.Println("---------------------------------")
 
 	fmt.Printf("Buscando preço para %s/BRL...\n", asset)
-	priceDataBRL, err := chainlinkService.GetPriceBRL(context.Background(), asset)
+	priceDataBRL, err := chainlinkService.GetPriceFiat(context.Background(), asset, "brl", service.QueryOptions{})
 	if err != nil {
 		log.Fatalf("Erro ao buscar preço em BRL: %v", err)
 	}
 	fmt.Printf("Par: %s\n", priceDataBRL.Pair)
-	fmt.Printf("Preço: %s\n", priceDataBRL.Price.String())
+	fmt.Printf("Preço: %s\n", priceDataBRL.Price.FloatString(8))
 	fmt.Printf("Última atualização (Timestamp): %d\n", priceDataBRL.Timestamp)
 }
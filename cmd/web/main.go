@@ -21,6 +21,10 @@ type PriceResponse struct {
 	ImageURL  string `json:"imageUrl"`
 }
 
+type AllPricesResponse struct {
+	Prices []PriceResponse `json:"prices"`
+}
+
 type PriceViewModel struct {
 	Pair           string
 	Price          string
@@ -67,8 +71,8 @@ func pricesHandler(c *gin.Context) {
 		return
 	}
 
-	var prices []PriceResponse
-	if err := json.Unmarshal(body, &prices); err != nil {
+	var allPrices AllPricesResponse
+	if err := json.Unmarshal(body, &allPrices); err != nil {
 		log.Printf("Erro ao fazer parse do JSON: %v. Body: %s", err, string(body))
 		c.String(http.StatusInternalServerError, "Erro ao processar dados de preços")
 		return
@@ -79,8 +83,8 @@ func pricesHandler(c *gin.Context) {
 		currencySymbol = "$"
 	}
 
-	viewModels := make([]PriceViewModel, len(prices))
-	for i, p := range prices {
+	viewModels := make([]PriceViewModel, len(allPrices.Prices))
+	for i, p := range allPrices.Prices {
 		viewModels[i] = PriceViewModel{
 			Pair:           p.Pair,
 			Price:          p.Price,
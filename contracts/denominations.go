@@ -0,0 +1,58 @@
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FeedRegistryAddress é o endereço do Feed Registry da Chainlink, disponível
+// apenas na rede principal da Ethereum.
+const FeedRegistryAddress = "0x47Fb2585D2C56Fe188D0E6ec628a38b74fCeeeDf"
+
+// Denominations reproduz a biblioteca Denominations da Chainlink: ativos
+// nativos usam endereços fixos, moedas fiduciárias usam o código numérico
+// ISO 4217 como endereço e tokens ERC-20 usam o próprio endereço do token.
+var Denominations = map[string]common.Address{
+	"eth": common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE"),
+	"btc": common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"),
+
+	"usd": common.HexToAddress("0x0000000000000000000000000000000000000348"),
+	"gbp": common.HexToAddress("0x000000000000000000000000000000000000033a"),
+	"eur": common.HexToAddress("0x00000000000000000000000000000000000003d2"),
+	"jpy": common.HexToAddress("0x0000000000000000000000000000000000000188"),
+
+	"1inch": common.HexToAddress("0x111111111117dC0aa78b770fA6A738034120C302"),
+	"aave":  common.HexToAddress("0x7Fc66500c84A76Ad7e9c93437bFc5Ac33E2DDaE9"),
+	"comp":  common.HexToAddress("0xc00e94Cb662C3520282E6f5717214004A7f26888"),
+	"crv":   common.HexToAddress("0xD533a949740bb3306d119CC777fa900bA034cd52"),
+	"link":  common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA"),
+	"mkr":   common.HexToAddress("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2"),
+	"paxg":  common.HexToAddress("0x45804880De22913dAFE09f4980848ECE6EcbAf78"),
+	"sushi": common.HexToAddress("0x6B3595068778DD592e39A122f4f5a5cF09C90fE2"),
+	"uni":   common.HexToAddress("0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"),
+	"yfi":   common.HexToAddress("0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"),
+}
+
+// ResolveDenomination retorna o endereço usado pelo Feed Registry para o
+// símbolo informado. Um endereço hexadecimal é aceito diretamente, para
+// tokens que não estão na lista.
+func ResolveDenomination(symbol string) (common.Address, bool) {
+	if address, ok := Denominations[strings.ToLower(symbol)]; ok {
+		return address, true
+	}
+	if common.IsHexAddress(symbol) {
+		return common.HexToAddress(symbol), true
+	}
+	return common.Address{}, false
+}
+
+// isoCodeLimit limita os códigos numéricos ISO 4217, que têm três dígitos.
+var isoCodeLimit = big.NewInt(1000)
+
+// IsFiatDenomination indica se o endereço representa uma moeda fiduciária,
+// identificada pelo código numérico ISO 4217.
+func IsFiatDenomination(address common.Address) bool {
+	return address.Big().Cmp(isoCodeLimit) < 0
+}
@@ -0,0 +1,714 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// FeedRegistryMetaData contains all meta data concerning the FeedRegistry contract.
+var FeedRegistryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"latestRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"},{\"internalType\":\"uint80\",\"name\":\"_roundId\",\"type\":\"uint80\"}],\"name\":\"getRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"description\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"version\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"getFeed\",\"outputs\":[{\"internalType\":\"contractAggregatorV2V3Interface\",\"name\":\"aggregator\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"getCurrentPhaseId\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"currentPhaseId\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"},{\"internalType\":\"uint16\",\"name\":\"phaseId\",\"type\":\"uint16\"}],\"name\":\"getPhaseFeed\",\"outputs\":[{\"internalType\":\"contractAggregatorV2V3Interface\",\"name\":\"aggregator\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"roundId\",\"type\":\"uint256\"}],\"name\":\"getTimestamp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"name\":\"isFeedEnabled\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"denomination\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"latestAggregator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"previousAggregator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint16\",\"name\":\"nextPhaseId\",\"type\":\"uint16\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"FeedConfirmed\",\"type\":\"event\"}]",
+}
+
+// FeedRegistryABI is the input ABI used to generate the binding from.
+// Deprecated: Use FeedRegistryMetaData.ABI instead.
+var FeedRegistryABI = FeedRegistryMetaData.ABI
+
+// FeedRegistry is an auto generated Go binding around an Ethereum contract.
+type FeedRegistry struct {
+	FeedRegistryCaller     // Read-only binding to the contract
+	FeedRegistryTransactor // Write-only binding to the contract
+	FeedRegistryFilterer   // Log filterer for contract events
+}
+
+// FeedRegistryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FeedRegistryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FeedRegistryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FeedRegistryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistrySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FeedRegistrySession struct {
+	Contract     *FeedRegistry     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// FeedRegistryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FeedRegistryCallerSession struct {
+	Contract *FeedRegistryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// FeedRegistryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FeedRegistryTransactorSession struct {
+	Contract     *FeedRegistryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// FeedRegistryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FeedRegistryRaw struct {
+	Contract *FeedRegistry // Generic contract binding to access the raw methods on
+}
+
+// FeedRegistryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FeedRegistryCallerRaw struct {
+	Contract *FeedRegistryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FeedRegistryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FeedRegistryTransactorRaw struct {
+	Contract *FeedRegistryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFeedRegistry creates a new instance of FeedRegistry, bound to a specific deployed contract.
+func NewFeedRegistry(address common.Address, backend bind.ContractBackend) (*FeedRegistry, error) {
+	contract, err := bindFeedRegistry(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistry{FeedRegistryCaller: FeedRegistryCaller{contract: contract}, FeedRegistryTransactor: FeedRegistryTransactor{contract: contract}, FeedRegistryFilterer: FeedRegistryFilterer{contract: contract}}, nil
+}
+
+// NewFeedRegistryCaller creates a new read-only instance of FeedRegistry, bound to a specific deployed contract.
+func NewFeedRegistryCaller(address common.Address, caller bind.ContractCaller) (*FeedRegistryCaller, error) {
+	contract, err := bindFeedRegistry(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryCaller{contract: contract}, nil
+}
+
+// NewFeedRegistryTransactor creates a new write-only instance of FeedRegistry, bound to a specific deployed contract.
+func NewFeedRegistryTransactor(address common.Address, transactor bind.ContractTransactor) (*FeedRegistryTransactor, error) {
+	contract, err := bindFeedRegistry(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryTransactor{contract: contract}, nil
+}
+
+// NewFeedRegistryFilterer creates a new log filterer instance of FeedRegistry, bound to a specific deployed contract.
+func NewFeedRegistryFilterer(address common.Address, filterer bind.ContractFilterer) (*FeedRegistryFilterer, error) {
+	contract, err := bindFeedRegistry(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryFilterer{contract: contract}, nil
+}
+
+// bindFeedRegistry binds a generic wrapper to an already deployed contract.
+func bindFeedRegistry(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := FeedRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeedRegistry *FeedRegistryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FeedRegistry.Contract.FeedRegistryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeedRegistry *FeedRegistryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeedRegistry.Contract.FeedRegistryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeedRegistry *FeedRegistryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeedRegistry.Contract.FeedRegistryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeedRegistry *FeedRegistryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FeedRegistry.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeedRegistry *FeedRegistryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeedRegistry.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeedRegistry *FeedRegistryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeedRegistry.Contract.contract.Transact(opts, method, params...)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistry *FeedRegistryCaller) Decimals(opts *bind.CallOpts, base common.Address, quote common.Address) (uint8, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "decimals", base, quote)
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistry *FeedRegistrySession) Decimals(base common.Address, quote common.Address) (uint8, error) {
+	return _FeedRegistry.Contract.Decimals(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistry *FeedRegistryCallerSession) Decimals(base common.Address, quote common.Address) (uint8, error) {
+	return _FeedRegistry.Contract.Decimals(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistry *FeedRegistryCaller) Description(opts *bind.CallOpts, base common.Address, quote common.Address) (string, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "description", base, quote)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistry *FeedRegistrySession) Description(base common.Address, quote common.Address) (string, error) {
+	return _FeedRegistry.Contract.Description(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistry *FeedRegistryCallerSession) Description(base common.Address, quote common.Address) (string, error) {
+	return _FeedRegistry.Contract.Description(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// GetCurrentPhaseId is a free data retrieval call binding the contract method 0x30322818.
+//
+// Solidity: function getCurrentPhaseId(address base, address quote) view returns(uint16 currentPhaseId)
+func (_FeedRegistry *FeedRegistryCaller) GetCurrentPhaseId(opts *bind.CallOpts, base common.Address, quote common.Address) (uint16, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "getCurrentPhaseId", base, quote)
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// GetCurrentPhaseId is a free data retrieval call binding the contract method 0x30322818.
+//
+// Solidity: function getCurrentPhaseId(address base, address quote) view returns(uint16 currentPhaseId)
+func (_FeedRegistry *FeedRegistrySession) GetCurrentPhaseId(base common.Address, quote common.Address) (uint16, error) {
+	return _FeedRegistry.Contract.GetCurrentPhaseId(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// GetCurrentPhaseId is a free data retrieval call binding the contract method 0x30322818.
+//
+// Solidity: function getCurrentPhaseId(address base, address quote) view returns(uint16 currentPhaseId)
+func (_FeedRegistry *FeedRegistryCallerSession) GetCurrentPhaseId(base common.Address, quote common.Address) (uint16, error) {
+	return _FeedRegistry.Contract.GetCurrentPhaseId(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistryCaller) GetFeed(opts *bind.CallOpts, base common.Address, quote common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "getFeed", base, quote)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistrySession) GetFeed(base common.Address, quote common.Address) (common.Address, error) {
+	return _FeedRegistry.Contract.GetFeed(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistryCallerSession) GetFeed(base common.Address, quote common.Address) (common.Address, error) {
+	return _FeedRegistry.Contract.GetFeed(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// GetPhaseFeed is a free data retrieval call binding the contract method 0x52dbeb8b.
+//
+// Solidity: function getPhaseFeed(address base, address quote, uint16 phaseId) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistryCaller) GetPhaseFeed(opts *bind.CallOpts, base common.Address, quote common.Address, phaseId uint16) (common.Address, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "getPhaseFeed", base, quote, phaseId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetPhaseFeed is a free data retrieval call binding the contract method 0x52dbeb8b.
+//
+// Solidity: function getPhaseFeed(address base, address quote, uint16 phaseId) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistrySession) GetPhaseFeed(base common.Address, quote common.Address, phaseId uint16) (common.Address, error) {
+	return _FeedRegistry.Contract.GetPhaseFeed(&_FeedRegistry.CallOpts, base, quote, phaseId)
+}
+
+// GetPhaseFeed is a free data retrieval call binding the contract method 0x52dbeb8b.
+//
+// Solidity: function getPhaseFeed(address base, address quote, uint16 phaseId) view returns(address aggregator)
+func (_FeedRegistry *FeedRegistryCallerSession) GetPhaseFeed(base common.Address, quote common.Address, phaseId uint16) (common.Address, error) {
+	return _FeedRegistry.Contract.GetPhaseFeed(&_FeedRegistry.CallOpts, base, quote, phaseId)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0xfc58749e.
+//
+// Solidity: function getRoundData(address base, address quote, uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistryCaller) GetRoundData(opts *bind.CallOpts, base common.Address, quote common.Address, _roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "getRoundData", base, quote, _roundId)
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RoundId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartedAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UpdatedAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AnsweredInRound = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0xfc58749e.
+//
+// Solidity: function getRoundData(address base, address quote, uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistrySession) GetRoundData(base common.Address, quote common.Address, _roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistry.Contract.GetRoundData(&_FeedRegistry.CallOpts, base, quote, _roundId)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0xfc58749e.
+//
+// Solidity: function getRoundData(address base, address quote, uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistryCallerSession) GetRoundData(base common.Address, quote common.Address, _roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistry.Contract.GetRoundData(&_FeedRegistry.CallOpts, base, quote, _roundId)
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0x91624c95.
+//
+// Solidity: function getTimestamp(address base, address quote, uint256 roundId) view returns(uint256)
+func (_FeedRegistry *FeedRegistryCaller) GetTimestamp(opts *bind.CallOpts, base common.Address, quote common.Address, roundId *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "getTimestamp", base, quote, roundId)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0x91624c95.
+//
+// Solidity: function getTimestamp(address base, address quote, uint256 roundId) view returns(uint256)
+func (_FeedRegistry *FeedRegistrySession) GetTimestamp(base common.Address, quote common.Address, roundId *big.Int) (*big.Int, error) {
+	return _FeedRegistry.Contract.GetTimestamp(&_FeedRegistry.CallOpts, base, quote, roundId)
+}
+
+// GetTimestamp is a free data retrieval call binding the contract method 0x91624c95.
+//
+// Solidity: function getTimestamp(address base, address quote, uint256 roundId) view returns(uint256)
+func (_FeedRegistry *FeedRegistryCallerSession) GetTimestamp(base common.Address, quote common.Address, roundId *big.Int) (*big.Int, error) {
+	return _FeedRegistry.Contract.GetTimestamp(&_FeedRegistry.CallOpts, base, quote, roundId)
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistry *FeedRegistryCaller) IsFeedEnabled(opts *bind.CallOpts, aggregator common.Address) (bool, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "isFeedEnabled", aggregator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistry *FeedRegistrySession) IsFeedEnabled(aggregator common.Address) (bool, error) {
+	return _FeedRegistry.Contract.IsFeedEnabled(&_FeedRegistry.CallOpts, aggregator)
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistry *FeedRegistryCallerSession) IsFeedEnabled(aggregator common.Address) (bool, error) {
+	return _FeedRegistry.Contract.IsFeedEnabled(&_FeedRegistry.CallOpts, aggregator)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistryCaller) LatestRoundData(opts *bind.CallOpts, base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "latestRoundData", base, quote)
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RoundId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartedAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UpdatedAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AnsweredInRound = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistrySession) LatestRoundData(base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistry.Contract.LatestRoundData(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistry *FeedRegistryCallerSession) LatestRoundData(base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistry.Contract.LatestRoundData(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// Version is a free data retrieval call binding the contract method 0xaf34b03a.
+//
+// Solidity: function version(address base, address quote) view returns(uint256)
+func (_FeedRegistry *FeedRegistryCaller) Version(opts *bind.CallOpts, base common.Address, quote common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _FeedRegistry.contract.Call(opts, &out, "version", base, quote)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0xaf34b03a.
+//
+// Solidity: function version(address base, address quote) view returns(uint256)
+func (_FeedRegistry *FeedRegistrySession) Version(base common.Address, quote common.Address) (*big.Int, error) {
+	return _FeedRegistry.Contract.Version(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// Version is a free data retrieval call binding the contract method 0xaf34b03a.
+//
+// Solidity: function version(address base, address quote) view returns(uint256)
+func (_FeedRegistry *FeedRegistryCallerSession) Version(base common.Address, quote common.Address) (*big.Int, error) {
+	return _FeedRegistry.Contract.Version(&_FeedRegistry.CallOpts, base, quote)
+}
+
+// FeedRegistryFeedConfirmedIterator is returned from FilterFeedConfirmed and is used to iterate over the raw logs and unpacked data for FeedConfirmed events raised by the FeedRegistry contract.
+type FeedRegistryFeedConfirmedIterator struct {
+	Event *FeedRegistryFeedConfirmed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *FeedRegistryFeedConfirmedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(FeedRegistryFeedConfirmed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(FeedRegistryFeedConfirmed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *FeedRegistryFeedConfirmedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *FeedRegistryFeedConfirmedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FeedRegistryFeedConfirmed represents a FeedConfirmed event raised by the FeedRegistry contract.
+type FeedRegistryFeedConfirmed struct {
+	Asset              common.Address
+	Denomination       common.Address
+	LatestAggregator   common.Address
+	PreviousAggregator common.Address
+	NextPhaseId        uint16
+	Sender             common.Address
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeedConfirmed is a free log retrieval operation binding the contract event 0x27a180c70f2642f63d1694eb252b7df52e7ab2565e3f67adf7748acb7d82b9bc.
+//
+// Solidity: event FeedConfirmed(address indexed asset, address indexed denomination, address indexed latestAggregator, address previousAggregator, uint16 nextPhaseId, address sender)
+func (_FeedRegistry *FeedRegistryFilterer) FilterFeedConfirmed(opts *bind.FilterOpts, asset []common.Address, denomination []common.Address, latestAggregator []common.Address) (*FeedRegistryFeedConfirmedIterator, error) {
+
+	var assetRule []interface{}
+	for _, assetItem := range asset {
+		assetRule = append(assetRule, assetItem)
+	}
+	var denominationRule []interface{}
+	for _, denominationItem := range denomination {
+		denominationRule = append(denominationRule, denominationItem)
+	}
+	var latestAggregatorRule []interface{}
+	for _, latestAggregatorItem := range latestAggregator {
+		latestAggregatorRule = append(latestAggregatorRule, latestAggregatorItem)
+	}
+
+	logs, sub, err := _FeedRegistry.contract.FilterLogs(opts, "FeedConfirmed", assetRule, denominationRule, latestAggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryFeedConfirmedIterator{contract: _FeedRegistry.contract, event: "FeedConfirmed", logs: logs, sub: sub}, nil
+}
+
+// WatchFeedConfirmed is a free log subscription operation binding the contract event 0x27a180c70f2642f63d1694eb252b7df52e7ab2565e3f67adf7748acb7d82b9bc.
+//
+// Solidity: event FeedConfirmed(address indexed asset, address indexed denomination, address indexed latestAggregator, address previousAggregator, uint16 nextPhaseId, address sender)
+func (_FeedRegistry *FeedRegistryFilterer) WatchFeedConfirmed(opts *bind.WatchOpts, sink chan<- *FeedRegistryFeedConfirmed, asset []common.Address, denomination []common.Address, latestAggregator []common.Address) (event.Subscription, error) {
+
+	var assetRule []interface{}
+	for _, assetItem := range asset {
+		assetRule = append(assetRule, assetItem)
+	}
+	var denominationRule []interface{}
+	for _, denominationItem := range denomination {
+		denominationRule = append(denominationRule, denominationItem)
+	}
+	var latestAggregatorRule []interface{}
+	for _, latestAggregatorItem := range latestAggregator {
+		latestAggregatorRule = append(latestAggregatorRule, latestAggregatorItem)
+	}
+
+	logs, sub, err := _FeedRegistry.contract.WatchLogs(opts, "FeedConfirmed", assetRule, denominationRule, latestAggregatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(FeedRegistryFeedConfirmed)
+				if err := _FeedRegistry.contract.UnpackLog(event, "FeedConfirmed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeedConfirmed is a log parse operation binding the contract event 0x27a180c70f2642f63d1694eb252b7df52e7ab2565e3f67adf7748acb7d82b9bc.
+//
+// Solidity: event FeedConfirmed(address indexed asset, address indexed denomination, address indexed latestAggregator, address previousAggregator, uint16 nextPhaseId, address sender)
+func (_FeedRegistry *FeedRegistryFilterer) ParseFeedConfirmed(log types.Log) (*FeedRegistryFeedConfirmed, error) {
+	event := new(FeedRegistryFeedConfirmed)
+	if err := _FeedRegistry.contract.UnpackLog(event, "FeedConfirmed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,21 @@
+package contracts
+
+import "math/big"
+
+// Os proxies da Chainlink codificam o roundId como
+// phaseId << 64 | aggregatorRoundId, onde phaseId identifica o agregador
+// que estava ativo quando o round foi produzido.
+const phaseOffset = 64
+
+var aggregatorRoundMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), phaseOffset), big.NewInt(1))
+
+func EncodeRoundID(phaseID uint16, aggregatorRoundID uint64) *big.Int {
+	roundID := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(phaseID)), phaseOffset)
+	return roundID.Or(roundID, new(big.Int).SetUint64(aggregatorRoundID))
+}
+
+func DecodeRoundID(roundID *big.Int) (phaseID uint16, aggregatorRoundID uint64) {
+	phaseID = uint16(new(big.Int).Rsh(roundID, phaseOffset).Uint64())
+	aggregatorRoundID = new(big.Int).And(roundID, aggregatorRoundMask).Uint64()
+	return phaseID, aggregatorRoundID
+}
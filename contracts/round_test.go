@@ -0,0 +1,41 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRoundID(t *testing.T) {
+	tests := []struct {
+		name              string
+		phaseID           uint16
+		aggregatorRoundID uint64
+		want              string
+	}{
+		{"fase 0", 0, 42, "42"},
+		{"primeiro round da fase 1", 1, 1, "18446744073709551617"},
+		{"round de produção", 6, 23, "110680464442257309719"},
+		{"maior round da fase", 2, ^uint64(0), "55340232221128654847"},
+		{"maior fase", ^uint16(0), 1, "1208907372870555465154561"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roundID := EncodeRoundID(tt.phaseID, tt.aggregatorRoundID)
+			if roundID.String() != tt.want {
+				t.Errorf("EncodeRoundID(%d, %d) = %s, esperado %s", tt.phaseID, tt.aggregatorRoundID, roundID, tt.want)
+			}
+
+			phaseID, aggregatorRoundID := DecodeRoundID(roundID)
+			if phaseID != tt.phaseID || aggregatorRoundID != tt.aggregatorRoundID {
+				t.Errorf("DecodeRoundID(%s) = (%d, %d), esperado (%d, %d)", roundID, phaseID, aggregatorRoundID, tt.phaseID, tt.aggregatorRoundID)
+			}
+		})
+	}
+}
+
+func TestDecodeRoundIDPlainNumber(t *testing.T) {
+	phaseID, aggregatorRoundID := DecodeRoundID(big.NewInt(7))
+	if phaseID != 0 || aggregatorRoundID != 7 {
+		t.Errorf("DecodeRoundID(7) = (%d, %d), esperado (0, 7)", phaseID, aggregatorRoundID)
+	}
+}
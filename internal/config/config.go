@@ -3,13 +3,27 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	RpcURL     string
+	// RpcURLs e WsRpcURLs são indexadas pelo nome da rede. Redes sem RPC
+	// configurada ficam desativadas.
+	RpcURLs    map[string]string
+	WsRpcURLs  map[string]string
 	ServerPort string
+	FeedsFile  string
+	CacheTTL   time.Duration
+	FXCacheTTL time.Duration
+	// FXProviders é a ordem em que os provedores de câmbio são consultados.
+	FXProviders []string
+	Poller      bool
+	// FeedRegistry resolve pelo Feed Registry da Chainlink os ativos que não
+	// estão no registro de feeds (somente Ethereum).
+	FeedRegistry bool
 }
 
 func Load() *Config {
@@ -19,7 +33,64 @@ func Load() *Config {
 	}
 
 	return &Config{
-		RpcURL:     os.Getenv("RPC_URL"),
-		ServerPort: os.Getenv("SERVER_PORT"),
+		RpcURLs:      networkEnv("RPC_URL"),
+		WsRpcURLs:    networkEnv("WS_RPC_URL"),
+		ServerPort:   os.Getenv("SERVER_PORT"),
+		FeedsFile:    stringEnv("FEEDS_FILE", "feeds.yaml"),
+		CacheTTL:     durationEnv("CACHE_TTL", 15*time.Second),
+		FXCacheTTL:   durationEnv("FX_CACHE_TTL", 10*time.Minute),
+		FXProviders:  listEnv("FX_PROVIDERS", DefaultFXProviders),
+		Poller:       os.Getenv("POLLER_ENABLED") != "false",
+		FeedRegistry: os.Getenv("FEED_REGISTRY_ENABLED") == "true",
 	}
 }
+
+// networkEnv lê uma variável por rede: a rede padrão usa a própria chave
+// (RPC_URL) e as demais usam o nome da rede como sufixo (RPC_URL_ARBITRUM).
+func networkEnv(key string) map[string]string {
+	values := make(map[string]string)
+	for name := range Networks {
+		envKey := key
+		if name != DefaultNetwork {
+			envKey = key + "_" + strings.ToUpper(name)
+		}
+		if value := os.Getenv(envKey); value != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+func stringEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func listEnv(key string, fallback []string) []string {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Aviso: valor inválido para %s (%q), usando %s", key, raw, fallback)
+		return fallback
+	}
+	return value
+}
@@ -1,12 +0,0 @@
-package config
-
-var ContractAddresses = map[string]string{
-	"1inch": "0xc929ad75B72593967DE83E7F7Cda0493458261D9", // 1INCH/USD
-	"link":  "0x76F8C9E423C228E83DCB11d17F0Bd8aEB0Ca01bb", // LINK/USD
-	"btc":   "0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c", // BTC/USD
-	"eth":   "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419", // ETH/USD
-	"paxg":  "0x9944D86CEB9160aF5C5feB251FD671923323f8C3", // PAXG/USD
-	"stx":   "0x2D27d9e1b74936D8E83c4BA118F09A4c4a897f62", // STX/USD
-	"uni":   "0x553303d460EE0afB37EdFf9bE42922D8FF63220e", // UNI/USD
-
-}
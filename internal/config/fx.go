@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+// FiatFeed é um feed da Chainlink na Ethereum que cota uma moeda fiduciária
+// em USD (ex: BRL/USD), usado como provedor de câmbio.
+type FiatFeed struct {
+	Name      string
+	Address   string
+	Heartbeat time.Duration
+}
+
+var FiatFeeds = map[string]FiatFeed{
+	"AUD": {Name: "Australian Dollar", Address: "0x77F9710E7d0A19669A13c055F62cd80d313dF022", Heartbeat: 24 * time.Hour},
+	"BRL": {Name: "Brazilian Real", Address: "0x971E8F1B779A5F1C36e1cd7ef44Ba1Cc2F5EeE0f", Heartbeat: 24 * time.Hour},
+	"CHF": {Name: "Swiss Franc", Address: "0x449d117117838fFA61263B61dA6301AA2a88B13A", Heartbeat: 24 * time.Hour},
+	"EUR": {Name: "Euro", Address: "0xb49f677943BC038e9857d61E7d053CaA2C1734C1", Heartbeat: 24 * time.Hour},
+	"GBP": {Name: "British Pound", Address: "0x5c0Ab2d9b5a7ed9f470386e82BB36A3613cDd4b5", Heartbeat: 24 * time.Hour},
+	"JPY": {Name: "Japanese Yen", Address: "0xBcE206caE7f0ec07b545EddE332A47C2F75bbeb3", Heartbeat: 24 * time.Hour},
+}
+
+// Provedores de câmbio aceitos em FX_PROVIDERS.
+const (
+	FXProviderFrankfurter = "frankfurter"
+	FXProviderChainlink   = "chainlink"
+	FXProviderPTAX        = "ptax"
+)
+
+// DefaultFXProviders é a ordem de prioridade padrão dos provedores.
+var DefaultFXProviders = []string{FXProviderFrankfurter, FXProviderChainlink, FXProviderPTAX}
@@ -0,0 +1,18 @@
+package config
+
+const DefaultNetwork = "ethereum"
+
+// Network descreve uma rede suportada. O ChainID é conferido na conexão,
+// para evitar que uma RPC da rede errada sirva preços de outros contratos.
+type Network struct {
+	Name    string
+	ChainID uint64
+}
+
+var Networks = map[string]Network{
+	"ethereum": {Name: "ethereum", ChainID: 1},
+	"arbitrum": {Name: "arbitrum", ChainID: 42161},
+	"optimism": {Name: "optimism", ChainID: 10},
+	"base":     {Name: "base", ChainID: 8453},
+	"polygon":  {Name: "polygon", ChainID: 137},
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultQuote = "usd"
+
+const (
+	// DefaultPrecision é a quantidade de casas decimais do preço exibido
+	// quando o feed não define outra.
+	DefaultPrecision = 2
+	MaxPrecision     = 18
+)
+
+// Deviation é o limiar de desvio (em %) que dispara uma nova resposta do
+// feed. Decimals, quando diferente de zero, substitui o valor lido do
+// contrato. CacheTTL sobrescreve, para o feed, o TTL padrão do cache de
+// preços (CACHE_TTL); zero usa o padrão. Precision é a quantidade de casas
+// decimais do preço exibido; nil usa DefaultPrecision. Category agrupa os
+// ativos para os filtros de /api/prices.
+type Feed struct {
+	Address   string        `yaml:"address"`
+	Quote     string        `yaml:"quote"`
+	Heartbeat time.Duration `yaml:"heartbeat"`
+	Deviation float64       `yaml:"deviation"`
+	Decimals  uint8         `yaml:"decimals"`
+	Logo      string        `yaml:"logo"`
+	CacheTTL  time.Duration `yaml:"cacheTTL"`
+	Precision *int          `yaml:"precision"`
+	Category  string        `yaml:"category"`
+}
+
+// Equal compara os feeds campo a campo. Precision é comparada pelo valor, e
+// não pelo ponteiro, já que cada leitura do registro cria um novo.
+func (f Feed) Equal(other Feed) bool {
+	if (f.Precision == nil) != (other.Precision == nil) ||
+		(f.Precision != nil && *f.Precision != *other.Precision) {
+		return false
+	}
+	f.Precision, other.Precision = nil, nil
+	return f == other
+}
+
+// FeedKey identifica um feed dentro de uma rede. Feeds cotados na moeda
+// padrão são indexados pelo próprio ativo, que é o usado nas rotas de preço;
+// os demais usam "ativo/cotação", permitindo vários feeds do mesmo ativo.
+func FeedKey(asset, quote string) string {
+	if quote == DefaultQuote {
+		return asset
+	}
+	return asset + "/" + quote
+}
+
+// FeedAsset retorna o ativo de uma chave criada por FeedKey.
+func FeedAsset(key string) string {
+	asset, _, _ := strings.Cut(key, "/")
+	return asset
+}
+
+type registryEntry struct {
+	Asset   string `yaml:"asset"`
+	Network string `yaml:"network"`
+	Feed    `yaml:",inline"`
+}
+
+type registryFile struct {
+	Feeds []registryEntry `yaml:"feeds"`
+}
+
+// Registry é o conjunto de feeds carregado do arquivo de registro, indexado
+// por rede e ativo.
+type Registry struct {
+	feeds map[string]map[string]Feed
+}
+
+var (
+	assetPattern    = regexp.MustCompile(`^[a-z0-9]+$`)
+	categoryPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// LoadRegistry lê e valida o arquivo de registro. JSON também é aceito, por
+// ser um subconjunto de YAML.
+func LoadRegistry(path string) (*Registry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o registro de feeds: %w", err)
+	}
+
+	var file registryFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("falha ao interpretar o registro de feeds %s: %w", path, err)
+	}
+
+	registry := &Registry{feeds: make(map[string]map[string]Feed)}
+	var errs []error
+	for i, entry := range file.Feeds {
+		entry.Asset = strings.ToLower(strings.TrimSpace(entry.Asset))
+		entry.Network = strings.ToLower(strings.TrimSpace(entry.Network))
+		entry.Quote = strings.ToLower(strings.TrimSpace(entry.Quote))
+		entry.Category = strings.ToLower(strings.TrimSpace(entry.Category))
+		if entry.Quote == "" {
+			entry.Quote = DefaultQuote
+		}
+
+		if err := entry.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("feed %d (%s em %s): %w", i+1, entry.Asset, entry.Network, err))
+			continue
+		}
+
+		feeds, ok := registry.feeds[entry.Network]
+		if !ok {
+			feeds = make(map[string]Feed)
+			registry.feeds[entry.Network] = feeds
+		}
+		key := FeedKey(entry.Asset, entry.Quote)
+		if _, duplicated := feeds[key]; duplicated {
+			errs = append(errs, fmt.Errorf("feed %d: par %s/%s duplicado na rede %s", i+1, entry.Asset, entry.Quote, entry.Network))
+			continue
+		}
+		feeds[key] = entry.Feed
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("registro de feeds %s inválido: %w", path, err)
+	}
+	return registry, nil
+}
+
+func (e registryEntry) validate() error {
+	switch {
+	case !assetPattern.MatchString(e.Asset):
+		return fmt.Errorf("ativo '%s' inválido: use letras minúsculas e números", e.Asset)
+	case !assetPattern.MatchString(e.Quote):
+		return fmt.Errorf("moeda de cotação '%s' inválida", e.Quote)
+	case !common.IsHexAddress(e.Address):
+		return fmt.Errorf("endereço '%s' inválido", e.Address)
+	case e.Heartbeat <= 0:
+		return fmt.Errorf("heartbeat deve ser maior que zero")
+	case e.Deviation < 0:
+		return fmt.Errorf("deviation não pode ser negativo")
+	case e.CacheTTL < 0:
+		return fmt.Errorf("cacheTTL não pode ser negativo")
+	case e.Precision != nil && (*e.Precision < 0 || *e.Precision > MaxPrecision):
+		return fmt.Errorf("precision deve estar entre 0 e %d", MaxPrecision)
+	case e.Category != "" && !categoryPattern.MatchString(e.Category):
+		return fmt.Errorf("categoria '%s' inválida: use letras minúsculas, números e hífen", e.Category)
+	}
+	if _, ok := Networks[e.Network]; !ok {
+		return fmt.Errorf("rede '%s' não suportada", e.Network)
+	}
+	return nil
+}
+
+// NetworkFeeds retorna os feeds da rede, indexados por FeedKey.
+func (r *Registry) NetworkFeeds(network string) map[string]Feed {
+	feeds := make(map[string]Feed, len(r.feeds[network]))
+	for key, feed := range r.feeds[network] {
+		feeds[key] = feed
+	}
+	return feeds
+}
+
+// Logos retorna a URL da imagem de cada ativo, considerando todas as redes.
+func (r *Registry) Logos() map[string]string {
+	logos := make(map[string]string)
+	for _, feeds := range r.feeds {
+		for key, feed := range feeds {
+			if feed.Logo != "" {
+				logos[FeedAsset(key)] = feed.Logo
+			}
+		}
+	}
+	return logos
+}
+
+const registryReloadDelay = 500 * time.Millisecond
+
+// WatchRegistry relê o registro quando o arquivo muda ou quando o processo
+// recebe SIGHUP, chamando onChange com o novo conteúdo. Um arquivo inválido
+// é ignorado e o registro anterior continua valendo. Bloqueia até que ctx
+// seja cancelado.
+func WatchRegistry(ctx context.Context, path string, onChange func(*Registry)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("falha ao observar o registro de feeds: %w", err)
+	}
+	defer watcher.Close()
+
+	// O diretório é observado no lugar do arquivo porque editores e
+	// ConfigMaps substituem o arquivo em vez de alterá-lo.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("falha ao observar o registro de feeds: %w", err)
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	reload := func(reason string) {
+		registry, err := LoadRegistry(path)
+		if err != nil {
+			log.Printf("registro de feeds não recarregado: %v", err)
+			return
+		}
+		log.Printf("registro de feeds recarregado (%s)", reason)
+		onChange(registry)
+	}
+
+	// Uma única gravação pode gerar vários eventos; eles são agrupados.
+	debounce := time.NewTimer(registryReloadDelay)
+	debounce.Stop()
+
+	name := filepath.Clean(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hangup:
+			reload("SIGHUP")
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) == name && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce.Reset(registryReloadDelay)
+			}
+		case <-debounce.C:
+			reload("arquivo alterado")
+		case err := <-watcher.Errors:
+			log.Printf("erro ao observar o registro de feeds: %v", err)
+		}
+	}
+}
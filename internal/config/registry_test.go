@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestFeedEqual(t *testing.T) {
+	precision := func(value int) *int { return &value }
+
+	tests := []struct {
+		name string
+		a, b Feed
+		want bool
+	}{
+		{"sem precisão", Feed{Address: "0x1"}, Feed{Address: "0x1"}, true},
+		{"mesma precisão em ponteiros distintos", Feed{Precision: precision(4)}, Feed{Precision: precision(4)}, true},
+		{"precisões diferentes", Feed{Precision: precision(4)}, Feed{Precision: precision(2)}, false},
+		{"precisão só em um", Feed{Precision: precision(2)}, Feed{}, false},
+		{"outro campo diferente", Feed{Address: "0x1", Precision: precision(4)}, Feed{Address: "0x2", Precision: precision(4)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
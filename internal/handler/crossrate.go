@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// crossRateDecimals é a quantidade de casas decimais da taxa calculada, que
+// pode ser bem menor que 1 (ex: ETH/BTC).
+const crossRateDecimals = 18
+
+type CrossRateResponse struct {
+	Pair      string                 `json:"pair"`
+	Rate      string                 `json:"rate"`
+	Timestamp int64                  `json:"timestamp"`
+	Stale     bool                   `json:"stale"`
+	ImageURL  string                 `json:"imageUrl"`
+	Path      []string               `json:"path"`
+	Legs      []CrossRateLegResponse `json:"legs"`
+	Block     *BlockResponse         `json:"block,omitempty"`
+}
+
+// CrossRateLegResponse descreve um trecho da conversão. Feed é o par do feed
+// lido; quando inverse é true, a taxa do trecho é o inverso do seu preço.
+type CrossRateLegResponse struct {
+	From      string         `json:"from"`
+	To        string         `json:"to"`
+	Feed      string         `json:"feed"`
+	Inverse   bool           `json:"inverse"`
+	Rate      string         `json:"rate"`
+	Timestamp int64          `json:"timestamp"`
+	Round     *RoundResponse `json:"round,omitempty"`
+
+	Stale      bool   `json:"stale"`
+	AgeSeconds int64  `json:"ageSeconds"`
+	Warning    string `json:"warning,omitempty"`
+}
+
+func (h *PriceHandler) getCrossRate(c *gin.Context) {
+	base := strings.ToLower(c.Param("asset"))
+	quote := strings.ToLower(c.Param("quote"))
+
+	opts, ok := h.queryOptions(c)
+	if !ok {
+		return
+	}
+	precision, ok := precisionParam(c)
+	if !ok {
+		return
+	}
+
+	crossRate, cacheStatus, err := network(c).Cache.GetCrossRate(c.Request.Context(), base, quote, opts)
+	setCacheStatus(c, cacheStatus)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	imageURL, err := h.assetService.GetAssetImageURL(base)
+	if err != nil {
+		log.Printf("não foi possível obter a URL da imagem para o ativo %s: %v", base, err)
+	}
+
+	c.JSON(http.StatusOK, newCrossRateResponse(crossRate, imageURL, precision))
+}
+
+// newCrossRateResponse formata as taxas com crossRateDecimals casas, sem
+// zeros à direita, ou com a precisão pedida. Os rounds dos trechos são
+// exibidos com todos os decimais do feed.
+func newCrossRateResponse(crossRate *service.CrossRate, imageURL string, precision *int) CrossRateResponse {
+	formatRate := func(rate *big.Rat) string {
+		if precision != nil {
+			return rate.FloatString(*precision)
+		}
+		return formatRat(rate, crossRateDecimals)
+	}
+
+	response := CrossRateResponse{
+		Pair:      crossRate.Pair(),
+		Rate:      formatRate(crossRate.Rate),
+		Timestamp: crossRate.Timestamp,
+		Stale:     crossRate.Stale,
+		ImageURL:  imageURL,
+		Path:      []string{crossRate.Base},
+		Legs:      make([]CrossRateLegResponse, len(crossRate.Legs)),
+		Block:     newBlockResponse(crossRate.Block),
+	}
+
+	for i, leg := range crossRate.Legs {
+		roundPrecision := int(leg.Price.Round.Decimals)
+		if precision != nil {
+			roundPrecision = *precision
+		}
+		round := newRoundResponse(leg.Price.Round, roundPrecision)
+		response.Path = append(response.Path, leg.To)
+		response.Legs[i] = CrossRateLegResponse{
+			From:      leg.From,
+			To:        leg.To,
+			Feed:      leg.Price.Pair,
+			Inverse:   leg.Inverse,
+			Rate:      formatRate(leg.Rate()),
+			Timestamp: leg.Price.Timestamp,
+			Round:     &round,
+
+			Stale:      leg.Price.Stale,
+			AgeSeconds: leg.Price.AgeSeconds,
+			Warning:    leg.Price.Warning,
+		}
+	}
+	return response
+}
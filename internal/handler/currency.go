@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CurrencyResponse struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type CurrenciesResponse struct {
+	Currencies []CurrencyResponse `json:"currencies"`
+}
+
+func (h *PriceHandler) getCurrencies(c *gin.Context) {
+	currencies, err := h.rateSource.Currencies(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := CurrenciesResponse{Currencies: make([]CurrencyResponse, 0, len(currencies))}
+	for code, name := range currencies {
+		response.Currencies = append(response.Currencies, CurrencyResponse{Code: code, Name: name})
+	}
+	sort.Slice(response.Currencies, func(i, j int) bool {
+		return response.Currencies[i].Code < response.Currencies[j].Code
+	})
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Códigos de erro informados em ErrorResponse.Code e AssetErrorResponse.Code.
+const (
+	codeInvalidParameter    = "invalid_parameter"
+	codeUnsupportedNetwork  = "unsupported_network"
+	codeUnsupportedAsset    = "unsupported_asset"
+	codeUnsupportedCurrency = "unsupported_currency"
+	codeNoConversionPath    = "no_conversion_path"
+	codeNotFound            = "not_found"
+	codeStalePrice          = "stale_price"
+	codeInvalidAnswer       = "invalid_answer"
+	codeIncompleteRound     = "incomplete_round"
+	codeTimeout             = "timeout"
+	codeFXUnavailable       = "fx_unavailable"
+	codeFeedUnavailable     = "feed_unavailable"
+	codeServiceUnavailable  = "service_unavailable"
+	codeInternalError       = "internal_error"
+)
+
+// ErrorResponse é o corpo de todas as respostas de erro. Code identifica o
+// tipo da falha e RequestID permite localizá-la nos logs do servidor.
+type ErrorResponse struct {
+	Erro      string               `json:"erro"`
+	Code      string               `json:"code"`
+	RequestID string               `json:"requestId,omitempty"`
+	Errors    []AssetErrorResponse `json:"errors,omitempty"`
+}
+
+// classifyError associa uma falha do serviço ao status HTTP e ao código de
+// erro. Os casos são verificados em ordem: uma falha de câmbio causada por um
+// prazo esgotado é um timeout, e a falha da cadeia de câmbio vem antes dos
+// demais casos porque ela carrega os erros dos provedores, como o feed de
+// câmbio desatualizado, que não dizem respeito ao feed do ativo.
+func classifyError(err error) (status int, code string) {
+	switch {
+	case errors.Is(err, service.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, codeTimeout
+	case errors.Is(err, service.ErrFXUnavailable):
+		return http.StatusBadGateway, codeFXUnavailable
+	case errors.Is(err, service.ErrInvalidArgument):
+		return http.StatusBadRequest, codeInvalidParameter
+	case errors.Is(err, service.ErrUnsupportedAsset):
+		return http.StatusNotFound, codeUnsupportedAsset
+	case errors.Is(err, service.ErrUnsupportedCurrency):
+		return http.StatusNotFound, codeUnsupportedCurrency
+	case errors.Is(err, service.ErrNoConversionPath):
+		return http.StatusNotFound, codeNoConversionPath
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound, codeNotFound
+	case errors.Is(err, service.ErrStalePrice):
+		return http.StatusServiceUnavailable, codeStalePrice
+	case errors.Is(err, service.ErrInvalidAnswer):
+		return http.StatusServiceUnavailable, codeInvalidAnswer
+	case errors.Is(err, service.ErrIncompleteRound):
+		return http.StatusServiceUnavailable, codeIncompleteRound
+	case errors.Is(err, service.ErrUpstream):
+		return http.StatusBadGateway, codeFeedUnavailable
+	default:
+		return http.StatusInternalServerError, codeInternalError
+	}
+}
+
+func errorCode(err error) string {
+	_, code := classifyError(err)
+	return code
+}
+
+// respondError envia a falha do serviço com o status e o código
+// correspondentes.
+func respondError(c *gin.Context, err error) {
+	status, code := classifyError(err)
+	abortWithError(c, status, ErrorResponse{Erro: err.Error(), Code: code})
+}
+
+// respondInvalid rejeita um parâmetro inválido da requisição.
+func respondInvalid(c *gin.Context, message string) {
+	abortWithError(c, http.StatusBadRequest, ErrorResponse{Erro: message, Code: codeInvalidParameter})
+}
+
+// abortWithError completa o envelope com o ID da requisição e interrompe a
+// cadeia de handlers. Falhas do servidor são registradas no log.
+func abortWithError(c *gin.Context, status int, response ErrorResponse) {
+	response.RequestID = c.GetString(requestIDKey)
+	if status >= http.StatusInternalServerError {
+		log.Printf("requisição %s %s (%s) falhou com status %d: %s", c.Request.Method, c.Request.URL.Path, response.RequestID, status, response.Erro)
+	}
+	c.AbortWithStatusJSON(status, response)
+}
+
+const (
+	requestIDKey    = "requestID"
+	requestIDHeader = "X-Request-ID"
+)
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID identifica cada requisição pelo cabeçalho X-Request-ID, gerando
+// um ID quando o cliente não informa um válido. O ID é devolvido no mesmo
+// cabeçalho e no corpo das respostas de erro.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+)
+
+func TestClassifyError(t *testing.T) {
+	staleFeed := &service.FeedValidationError{Asset: "btc", Reason: service.ErrStalePrice}
+	fxChain := func(providerErrs ...error) error {
+		return fmt.Errorf("%w: falha ao buscar taxa USD/BRL: %w", service.ErrFXUnavailable, errors.Join(providerErrs...))
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"parâmetro inválido", fmt.Errorf("%w: limite", service.ErrInvalidArgument), http.StatusBadRequest, codeInvalidParameter},
+		{"ativo não suportado", fmt.Errorf("%w: 'xyz'", service.ErrUnsupportedAsset), http.StatusNotFound, codeUnsupportedAsset},
+		{"moeda não suportada", fmt.Errorf("%w: 'xyz'", service.ErrUnsupportedCurrency), http.StatusNotFound, codeUnsupportedCurrency},
+		{"sem conversão", service.ErrNoConversionPath, http.StatusNotFound, codeNoConversionPath},
+		{"feed desatualizado", staleFeed, http.StatusServiceUnavailable, codeStalePrice},
+		{"falha no nó", fmt.Errorf("%w: connection refused", service.ErrUpstream), http.StatusBadGateway, codeFeedUnavailable},
+		{"prazo esgotado", fmt.Errorf("falha: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, codeTimeout},
+		{"câmbio com feed de câmbio desatualizado", fxChain(staleFeed), http.StatusBadGateway, codeFXUnavailable},
+		{"câmbio sem round no instante", fxChain(fmt.Errorf("%w: round", service.ErrNotFound)), http.StatusBadGateway, codeFXUnavailable},
+		{"câmbio com prazo esgotado", fxChain(context.DeadlineExceeded), http.StatusGatewayTimeout, codeTimeout},
+		{"desconhecido", errors.New("falha"), http.StatusInternalServerError, codeInternalError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := classifyError(tt.err)
+			if status != tt.wantStatus || code != tt.wantCode {
+				t.Errorf("classifyError() = %d %s, esperado %d %s", status, code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
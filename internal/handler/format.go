@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// formatRat formata a fração em notação decimal, sem zeros à direita.
+func formatRat(value *big.Rat, decimals int) string {
+	text := value.FloatString(decimals)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
+
+// formatExact formata o valor com todas as suas casas decimais. Frações sem
+// representação decimal finita, como as de taxas invertidas, são
+// arredondadas em config.MaxPrecision casas.
+func formatExact(value *big.Rat) string {
+	if digits, ok := decimalDigits(value.Denom()); ok {
+		return value.FloatString(digits)
+	}
+	return formatRat(value, config.MaxPrecision)
+}
+
+// decimalDigits retorna quantas casas decimais representam exatamente uma
+// fração irredutível com o denominador informado. ok é false quando ele tem
+// fatores primos além de 2 e 5.
+func decimalDigits(denom *big.Int) (digits int, ok bool) {
+	rest := new(big.Int).Set(denom)
+	twos := int(rest.TrailingZeroBits())
+	rest.Rsh(rest, uint(twos))
+
+	five, remainder := big.NewInt(5), new(big.Int)
+	fives := 0
+	for {
+		quotient, _ := new(big.Int).QuoRem(rest, five, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		rest = quotient
+		fives++
+	}
+
+	return max(twos, fives), rest.IsInt64() && rest.Int64() == 1
+}
+
+// precisionFunc retorna as casas decimais do preço exibido de um ativo.
+type precisionFunc func(asset string) int
+
+// precisionParam interpreta o parâmetro de query 'precision', retornando nil
+// quando ele não foi informado. Em caso de erro a resposta 400 já é enviada
+// e ok é false.
+func precisionParam(c *gin.Context) (precision *int, ok bool) {
+	raw := c.Query("precision")
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed < 0 || parsed > config.MaxPrecision {
+		respondInvalid(c, fmt.Sprintf("parâmetro 'precision' inválido: use um inteiro entre 0 e %d", config.MaxPrecision))
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// pricePrecision usa o parâmetro 'precision' ou, na falta dele, a precisão
+// de cada ativo no registro de feeds.
+func pricePrecision(c *gin.Context) (precisionFunc, bool) {
+	requested, ok := precisionParam(c)
+	if !ok {
+		return nil, false
+	}
+	chainlink := network(c).Chainlink
+	return func(asset string) int {
+		if requested != nil {
+			return *requested
+		}
+		return chainlink.Precision(asset)
+	}, true
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDecimalDigits(t *testing.T) {
+	tests := []struct {
+		denom  int64
+		digits int
+		ok     bool
+	}{
+		{1, 0, true},
+		{2, 1, true},
+		{5, 1, true},
+		{8, 3, true},
+		{20, 2, true},
+		{100000000, 8, true},
+		{1 << 20, 20, true},
+		{3, 0, false},
+		{12, 2, false},
+		{2500 * 7, 4, false},
+	}
+	for _, tt := range tests {
+		digits, ok := decimalDigits(big.NewInt(tt.denom))
+		if ok != tt.ok || (ok && digits != tt.digits) {
+			t.Errorf("decimalDigits(%d) = (%d, %v), esperado (%d, %v)", tt.denom, digits, ok, tt.digits, tt.ok)
+		}
+	}
+}
+
+func TestFormatExact(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *big.Rat
+		want  string
+	}{
+		{"inteiro", big.NewRat(2500, 1), "2500"},
+		{"resposta de 8 decimais", big.NewRat(6500012345678, 100000000), "65000.12345678"},
+		{"zeros à direita são removidos pela fração", big.NewRat(150, 100), "1.5"},
+		{"fração binária", big.NewRat(1, 1<<10), "0.0009765625"},
+		{"taxa invertida sem representação finita", big.NewRat(1, 3), "0.333333333333333333"},
+		{"dízima com parte exata", big.NewRat(1, 6), "0.166666666666666667"},
+		{"negativo", big.NewRat(-5, 4), "-1.25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExact(tt.value); got != tt.want {
+				t.Errorf("formatExact(%s) = %s, esperado %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRat(t *testing.T) {
+	tests := []struct {
+		value    *big.Rat
+		decimals int
+		want     string
+	}{
+		{big.NewRat(1, 2), 4, "0.5"},
+		{big.NewRat(2, 1), 4, "2"},
+		{big.NewRat(1, 3), 4, "0.3333"},
+		{big.NewRat(2, 3), 2, "0.67"},
+		{big.NewRat(1000, 1), 0, "1000"},
+	}
+	for _, tt := range tests {
+		if got := formatRat(tt.value, tt.decimals); got != tt.want {
+			t.Errorf("formatRat(%s, %d) = %s, esperado %s", tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestPrecisionParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query      string
+		want       *int
+		wantOK     bool
+		wantStatus int
+	}{
+		{"", nil, true, http.StatusOK},
+		{"?precision=0", intPtr(0), true, http.StatusOK},
+		{"?precision=18", intPtr(18), true, http.StatusOK},
+		{"?precision=19", nil, false, http.StatusBadRequest},
+		{"?precision=-1", nil, false, http.StatusBadRequest},
+		{"?precision=abc", nil, false, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			got, ok := precisionParam(c)
+			if ok != tt.wantOK || recorder.Code != tt.wantStatus {
+				t.Fatalf("ok = %v com status %d, esperado %v com status %d", ok, recorder.Code, tt.wantOK, tt.wantStatus)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("precisão = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -2,50 +2,240 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
 	"github.com/dev-araujo/chainlink-price-feed/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
+// PriceResponse traz o preço arredondado para exibição em Price e com todas
+// as casas decimais em PriceExact. Answer e Decimals são a resposta inteira
+// do feed e seus decimais, a partir dos quais o preço em USD é calculado.
 type PriceResponse struct {
-	Pair      string `json:"pair"`
-	Price     string `json:"price"`
-	Timestamp int64  `json:"timestamp"`
-	ImageURL  string `json:"imageUrl"`
+	Pair       string         `json:"pair"`
+	Currency   string         `json:"currency"`
+	Price      string         `json:"price"`
+	PriceExact string         `json:"priceExact"`
+	Answer     string         `json:"answer"`
+	Decimals   uint8          `json:"decimals"`
+	Timestamp  int64          `json:"timestamp"`
+	ImageURL   string         `json:"imageUrl"`
+	Round      *RoundResponse `json:"round,omitempty"`
+	Block      *BlockResponse `json:"block,omitempty"`
+
+	Stale      bool   `json:"stale"`
+	AgeSeconds int64  `json:"ageSeconds"`
+	Warning    string `json:"warning,omitempty"`
+
+	SnapshotAgeSeconds *int64 `json:"snapshotAgeSeconds,omitempty"`
+	Degraded           bool   `json:"degraded,omitempty"`
+
+	FX *FXResponse `json:"fx,omitempty"`
+}
+
+type AllPricesResponse struct {
+	Currency string          `json:"currency"`
+	FX       *FXResponse     `json:"fx,omitempty"`
+	Block    *BlockResponse  `json:"block"`
+	Prices   []PriceResponse `json:"prices"`
+
+	SnapshotAgeSeconds *int64 `json:"snapshotAgeSeconds,omitempty"`
+	Degraded           bool   `json:"degraded,omitempty"`
+
+	// Errors lista os ativos que não puderam ser lidos. Quando não está
+	// vazia, a resposta usa o status 207.
+	Errors []AssetErrorResponse `json:"errors,omitempty"`
+}
+
+// AssetErrorResponse descreve a falha de um ativo em uma resposta com vários
+// ativos. Code identifica o tipo da falha (veja classifyError).
+type AssetErrorResponse struct {
+	Asset string `json:"asset"`
+	Code  string `json:"code"`
+	Erro  string `json:"erro"`
+}
+
+func newAssetErrorResponses(errs []*service.AssetError) []AssetErrorResponse {
+	if len(errs) == 0 {
+		return nil
+	}
+	responses := make([]AssetErrorResponse, len(errs))
+	for i, assetErr := range errs {
+		responses[i] = AssetErrorResponse{Asset: assetErr.Asset, Code: errorCode(assetErr.Err), Erro: assetErr.Err.Error()}
+	}
+	return responses
+}
+
+// FXResponse descreve a taxa de câmbio usada na conversão, a data de
+// referência e o provedor que a forneceu.
+type FXResponse struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Rate     string `json:"rate"`
+	Date     string `json:"date,omitempty"`
+	Provider string `json:"provider"`
+}
+
+func newFXResponse(rate *service.FXRate) *FXResponse {
+	if rate == nil {
+		return nil
+	}
+	response := &FXResponse{
+		From:     rate.From,
+		To:       rate.To,
+		Rate:     formatExact(rate.Rate),
+		Provider: rate.Provider,
+	}
+	if !rate.Date.IsZero() {
+		response.Date = rate.Date.Format(time.DateOnly)
+	}
+	return response
+}
+
+func sameFX(responses []PriceResponse) bool {
+	for _, response := range responses[1:] {
+		if response.FX == nil || *response.FX != *responses[0].FX {
+			return false
+		}
+	}
+	return true
+}
+
+type BlockResponse struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	Timestamp uint64 `json:"timestamp"`
 }
 
 type PriceHandler struct {
-	chainlinkService *service.ChainlinkService
-	assetService     *service.AssetService
+	networks     map[string]*service.Network
+	assetService *service.AssetService
+	rateSource   service.RateSource
 }
 
-func NewPriceHandler(cs *service.ChainlinkService, as *service.AssetService) *PriceHandler {
+func NewPriceHandler(networks map[string]*service.Network, as *service.AssetService, rateSource service.RateSource) *PriceHandler {
 	return &PriceHandler{
-		chainlinkService: cs,
-		assetService:     as,
+		networks:     networks,
+		assetService: as,
+		rateSource:   rateSource,
 	}
 }
 
 func (h *PriceHandler) RegisterRoutes(router *gin.Engine) {
-	api := router.Group("/api/price")
+	api := router.Group("/api/price", h.resolveNetwork)
 	{
 		api.GET("/:asset/usd", h.getPriceUsd)
-		api.GET("/:asset/brl", h.getPriceBrl)
+		api.GET("/:asset/history", h.getPriceHistory)
+		api.GET("/:asset/:quote", h.getPriceInQuote)
 		api.GET("/all/usd", h.getAllPricesUsd)
-		api.GET("/all/brl", h.getAllPricesBrl)
+		api.GET("/all/:quote", h.getAllPricesFiat)
 	}
+
+	router.GET("/api/prices", h.resolveNetwork, h.getPrices)
+	router.GET("/api/currencies", h.getCurrencies)
+
+	router.GET("/api/stream/prices", h.resolveNetwork, h.streamPrices)
+	router.GET("/api/ws/prices", h.resolveNetwork, h.priceWebSocket)
 }
 
-func (h *PriceHandler) getPrice(c *gin.Context, getPriceFunc func(ctx context.Context, asset string) (*service.PriceData, error)) {
+const networkKey = "network"
+
+// resolveNetwork seleciona a rede pelo parâmetro de query 'network', com a
+// rede padrão quando ele é omitido.
+func (h *PriceHandler) resolveNetwork(c *gin.Context) {
+	name := strings.ToLower(c.DefaultQuery("network", config.DefaultNetwork))
+	network, ok := h.networks[name]
+	if !ok {
+		abortWithError(c, http.StatusBadRequest, ErrorResponse{Erro: fmt.Sprintf("rede '%s' não suportada ou não configurada", name), Code: codeUnsupportedNetwork})
+		return
+	}
+	c.Set(networkKey, network)
+}
+
+func network(c *gin.Context) *service.Network {
+	return c.MustGet(networkKey).(*service.Network)
+}
+
+type priceFunc func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, service.CacheStatus, error)
+
+func uncached(fetch func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, error)) priceFunc {
+	return func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, service.CacheStatus, error) {
+		priceData, err := fetch(ctx, asset, opts)
+		return priceData, "", err
+	}
+}
+
+type batchFunc func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error)
+
+// snapshotStatus informa STALE quando o snapshot está sendo servido após
+// uma falha de atualização.
+func snapshotStatus(info *service.SnapshotInfo) service.CacheStatus {
+	if info.Degraded {
+		return service.CacheStale
+	}
+	return service.CacheHit
+}
+
+// fromSnapshot tenta servir o preço a partir do snapshot do poller, caindo
+// para fetch quando não há poller, a leitura está fixada em um bloco ou o
+// ativo ainda não foi carregado.
+func fromSnapshot(poller *service.Poller, snapshot func(asset string, mode service.ValidationMode) (*service.PriceData, bool, error), fetch priceFunc) priceFunc {
+	return func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, service.CacheStatus, error) {
+		if poller != nil && opts.Block == nil {
+			if priceData, ok, err := snapshot(asset, opts.Validation); ok {
+				if err != nil {
+					return nil, "", err
+				}
+				return priceData, snapshotStatus(priceData.Snapshot), nil
+			}
+		}
+		return fetch(ctx, asset, opts)
+	}
+}
+
+func batchFromSnapshot(poller *service.Poller, snapshot func(mode service.ValidationMode) (*service.PriceBatch, bool, error), fetch batchFunc) batchFunc {
+	return func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error) {
+		if poller != nil && opts.Block == nil {
+			if batch, ok, err := snapshot(opts.Validation); ok {
+				if err != nil {
+					return nil, "", err
+				}
+				return batch, snapshotStatus(batch.Snapshot), nil
+			}
+		}
+		return fetch(ctx, opts)
+	}
+}
+
+func setCacheStatus(c *gin.Context, status service.CacheStatus) {
+	if status != "" {
+		c.Header("X-Cache", string(status))
+	}
+}
+
+func (h *PriceHandler) getPrice(c *gin.Context, getPriceFunc priceFunc) {
 	asset := strings.ToLower(c.Param("asset"))
 
-	priceData, err := getPriceFunc(c.Request.Context(), asset)
+	opts, ok := h.queryOptions(c)
+	if !ok {
+		return
+	}
+	precision, ok := pricePrecision(c)
+	if !ok {
+		return
+	}
+
+	priceData, cacheStatus, err := getPriceFunc(c.Request.Context(), asset, opts)
+	setCacheStatus(c, cacheStatus)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"erro": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -54,47 +244,214 @@ func (h *PriceHandler) getPrice(c *gin.Context, getPriceFunc func(ctx context.Co
 		log.Printf("não foi possível obter a URL da imagem para o ativo %s: %v", asset, err)
 	}
 
-	c.JSON(http.StatusOK, PriceResponse{
-		Pair:      priceData.Pair,
-		Price:     priceData.Price.Text('f', 2),
-		Timestamp: priceData.Timestamp,
-		ImageURL:  imageURL,
-	})
+	c.JSON(http.StatusOK, newPriceResponse(priceData, imageURL, precision(asset)))
 }
 
-func (h *PriceHandler) getPriceUsd(c *gin.Context) {
-	h.getPrice(c, h.chainlinkService.GetPriceUSD)
+func newPriceResponse(data *service.PriceData, imageURL string, precision int) PriceResponse {
+	response := PriceResponse{
+		Pair:       data.Pair,
+		Currency:   data.Currency,
+		Price:      data.Price.FloatString(precision),
+		PriceExact: formatExact(data.Price),
+		Answer:     data.Round.Answer.String(),
+		Decimals:   data.Round.Decimals,
+		Timestamp:  data.Timestamp,
+		ImageURL:   imageURL,
+
+		Stale:      data.Stale,
+		AgeSeconds: data.AgeSeconds,
+		Warning:    data.Warning,
+	}
+	round := newRoundResponse(data.Round, precision)
+	response.Round = &round
+	response.Block = newBlockResponse(data.Block)
+	response.SnapshotAgeSeconds, response.Degraded = snapshotFields(data.Snapshot)
+	response.FX = newFXResponse(data.FX)
+	return response
 }
 
-func (h *PriceHandler) getPriceBrl(c *gin.Context) {
-	h.getPrice(c, h.chainlinkService.GetPriceBRL)
+func snapshotFields(info *service.SnapshotInfo) (ageSeconds *int64, degraded bool) {
+	if info == nil {
+		return nil, false
+	}
+	age := int64(info.Age() / time.Second)
+	return &age, info.Degraded
 }
 
-func (h *PriceHandler) getAllPricesUsd(c *gin.Context) {
-	priceData, err := h.chainlinkService.GetAllPricesUSD()
+// queryOptions interpreta os parâmetros de query comuns às leituras on-chain.
+// Em caso de erro a resposta 400 já é enviada e ok é false.
+func (h *PriceHandler) queryOptions(c *gin.Context) (opts service.QueryOptions, ok bool) {
+	if raw := c.Query("block"); raw != "" {
+		block, err := network(c).Chainlink.ResolveBlock(c.Request.Context(), raw)
+		if err != nil {
+			respondError(c, err)
+			return opts, false
+		}
+		opts.Block = block
+	}
+
+	switch c.DefaultQuery("mode", "lenient") {
+	case "lenient":
+		opts.Validation = service.ValidationLenient
+	case "strict":
+		opts.Validation = service.ValidationStrict
+	default:
+		respondInvalid(c, "parâmetro 'mode' inválido: use 'strict' ou 'lenient'")
+		return opts, false
+	}
+
+	return opts, true
+}
+
+// partialParam interpreta o parâmetro 'partial', que define se uma resposta
+// com vários ativos pode omitir os que falharem (padrão) ou deve falhar por
+// inteiro. Em caso de erro a resposta 400 já é enviada e ok é false.
+func partialParam(c *gin.Context) (partial, ok bool) {
+	partial, err := strconv.ParseBool(c.DefaultQuery("partial", "true"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"erro": err.Error()})
+		respondInvalid(c, "parâmetro 'partial' inválido: use 'true' ou 'false'")
+		return false, false
+	}
+	return partial, true
+}
+
+func newBlockResponse(block *service.BlockRef) *BlockResponse {
+	if block == nil {
+		return nil
+	}
+	return &BlockResponse{
+		Number:    block.Number.Uint64(),
+		Hash:      block.Hash.Hex(),
+		Timestamp: block.Timestamp,
+	}
+}
+
+func (h *PriceHandler) getPriceUsd(c *gin.Context) {
+	n := network(c)
+	if h.getPriceAt(c, n.Chainlink.GetPriceAt) {
+		return
+	}
+	h.getPrice(c, fromSnapshot(n.Poller, n.Poller.PriceUSD, n.Cache.GetPriceUSD))
+}
+
+// getPriceAt atende as consultas com o parâmetro 'at', retornando false
+// quando ele não foi informado.
+func (h *PriceHandler) getPriceAt(c *gin.Context, fetch func(ctx context.Context, asset string, at time.Time, opts service.QueryOptions) (*service.PriceData, error)) bool {
+	raw := c.Query("at")
+	if raw == "" {
+		return false
+	}
+
+	at, err := parseTimestamp(raw)
+	if err != nil {
+		respondInvalid(c, err.Error())
+		return true
+	}
+	h.getPrice(c, uncached(func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, error) {
+		return fetch(ctx, asset, at, opts)
+	}))
+	return true
+}
+
+func parseTimestamp(raw string) (time.Time, error) {
+	var at time.Time
+	if unix, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		at = time.Unix(unix, 0)
+	} else if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		at = parsed
+	} else {
+		return time.Time{}, fmt.Errorf("parâmetro 'at' inválido: use um timestamp unix ou RFC3339")
+	}
+
+	if at.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("parâmetro 'at' não pode estar no futuro")
+	}
+	return at, nil
+}
+
+// getPriceInQuote atende /:asset/:quote. Ativos e moedas presentes no grafo
+// de feeds são convertidos on-chain; as demais cotações são tratadas como
+// moedas fiduciárias, convertidas a partir do preço em USD. Moedas
+// fiduciárias do Feed Registry, como EUR, também seguem pelo câmbio.
+func (h *PriceHandler) getPriceInQuote(c *gin.Context) {
+	quote := strings.ToLower(c.Param("quote"))
+	if network(c).Chainlink.IsConvertible(quote) {
+		h.getCrossRate(c)
+		return
+	}
+	h.getPriceFiat(c, quote)
+}
+
+func (h *PriceHandler) getPriceFiat(c *gin.Context, currency string) {
+	n := network(c)
+	if h.getPriceAt(c, func(ctx context.Context, asset string, at time.Time, opts service.QueryOptions) (*service.PriceData, error) {
+		return n.Chainlink.GetPriceFiatAt(ctx, asset, currency, at, opts)
+	}) {
 		return
 	}
 
-	h.buildAndSendAllPricesResponse(c, priceData)
+	snapshot := func(asset string, mode service.ValidationMode) (*service.PriceData, bool, error) {
+		return n.Poller.PriceFiat(c.Request.Context(), asset, currency, mode)
+	}
+	h.getPrice(c, fromSnapshot(n.Poller, snapshot, func(ctx context.Context, asset string, opts service.QueryOptions) (*service.PriceData, service.CacheStatus, error) {
+		return n.Cache.GetPriceFiat(ctx, asset, currency, opts)
+	}))
+}
+
+func (h *PriceHandler) getAllPricesUsd(c *gin.Context) {
+	n := network(c)
+	h.getAllPrices(c, config.DefaultQuote, batchFromSnapshot(n.Poller, n.Poller.AllPricesUSD, n.Cache.GetAllPricesUSD))
+}
+
+func (h *PriceHandler) getAllPricesFiat(c *gin.Context) {
+	n := network(c)
+	currency := strings.ToLower(c.Param("quote"))
+	snapshot := func(mode service.ValidationMode) (*service.PriceBatch, bool, error) {
+		return n.Poller.AllPricesFiat(c.Request.Context(), currency, mode)
+	}
+	h.getAllPrices(c, currency, batchFromSnapshot(n.Poller, snapshot, func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error) {
+		return n.Cache.GetAllPricesFiat(ctx, currency, opts)
+	}))
 }
 
-func (h *PriceHandler) getAllPricesBrl(c *gin.Context) {
-	priceData, err := h.chainlinkService.GetAllPricesBRL()
+func (h *PriceHandler) getAllPrices(c *gin.Context, currency string, getBatchFunc batchFunc) {
+	opts, ok := h.queryOptions(c)
+	if !ok {
+		return
+	}
+	precision, ok := pricePrecision(c)
+	if !ok {
+		return
+	}
+	partial, ok := partialParam(c)
+	if !ok {
+		return
+	}
+
+	priceData, cacheStatus, err := getBatchFunc(c.Request.Context(), opts)
+	setCacheStatus(c, cacheStatus)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"erro": err.Error()})
+		respondError(c, err)
+		return
+	}
+	if err := priceData.Err(); err != nil && !partial {
+		status, code := classifyError(err)
+		abortWithError(c, status, ErrorResponse{
+			Erro:   fmt.Sprintf("%d ativo(s) não puderam ser lidos", len(priceData.Errors)),
+			Code:   code,
+			Errors: newAssetErrorResponses(priceData.Errors),
+		})
 		return
 	}
 
-	h.buildAndSendAllPricesResponse(c, priceData)
+	h.buildAndSendAllPricesResponse(c, currency, priceData, precision)
 }
 
-func (h *PriceHandler) buildAndSendAllPricesResponse(c *gin.Context, priceData []*service.PriceData) {
-	responses := make([]PriceResponse, len(priceData))
+func (h *PriceHandler) buildAndSendAllPricesResponse(c *gin.Context, currency string, batch *service.PriceBatch, precision precisionFunc) {
+	responses := make([]PriceResponse, len(batch.Prices))
 	var wg sync.WaitGroup
 
-	for i, p := range priceData {
+	for i, p := range batch.Prices {
 		wg.Add(1)
 		go func(index int, data *service.PriceData) {
 			defer wg.Done()
@@ -105,15 +462,36 @@ func (h *PriceHandler) buildAndSendAllPricesResponse(c *gin.Context, priceData [
 				log.Printf("não foi possível obter a URL da imagem para o ativo %s: %v", assetSymbol, err)
 			}
 
-			responses[index] = PriceResponse{
-				Pair:      data.Pair,
-				Price:     data.Price.Text('f', 2),
-				Timestamp: data.Timestamp,
-				ImageURL:  imageURL,
+			// O bloco é informado uma única vez quando todos os preços foram
+			// lidos nele; senão, cada preço traz o seu.
+			responses[index] = newPriceResponse(data, imageURL, precision(assetSymbol))
+			if batch.Block != nil {
+				responses[index].Block = nil
 			}
 		}(i, p)
 	}
 
 	wg.Wait()
-	c.JSON(http.StatusOK, responses)
+	response := AllPricesResponse{
+		Currency: strings.ToUpper(currency),
+		Block:    newBlockResponse(batch.Block),
+		Prices:   responses,
+	}
+	response.SnapshotAgeSeconds, response.Degraded = snapshotFields(batch.Snapshot)
+	response.Errors = newAssetErrorResponses(batch.Errors)
+
+	// Quando todos os preços usam a mesma taxa, ela é informada uma única vez.
+	// Leituras fixadas em um bloco podem usar uma taxa por preço.
+	if len(responses) > 0 && responses[0].FX != nil && sameFX(responses) {
+		response.FX = responses[0].FX
+		for i := range responses {
+			responses[i].FX = nil
+		}
+	}
+
+	status := http.StatusOK
+	if len(response.Errors) > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, response)
 }
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeRateSource registra as consultas de moedas, que só o caminho
+// fiduciário faz, e não conhece nenhuma moeda além do USD.
+type fakeRateSource struct {
+	currencyChecks int
+}
+
+func (s *fakeRateSource) GetRate(ctx context.Context, from, to string) (*service.FXRate, error) {
+	return nil, service.ErrUnsupportedCurrency
+}
+
+func (s *fakeRateSource) GetRateAt(ctx context.Context, from, to string, at time.Time) (*service.FXRate, error) {
+	return nil, service.ErrUnsupportedCurrency
+}
+
+func (s *fakeRateSource) Currencies(ctx context.Context) (map[string]string, error) {
+	s.currencyChecks++
+	return map[string]string{"USD": "Dólar americano"}, nil
+}
+
+func TestGetPriceInQuoteRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantFiat   bool
+		wantStatus int
+		wantCode   string
+	}{
+		{"moeda do Feed Registry segue pelo câmbio", "/api/price/btc/eur", true, http.StatusNotFound, codeUnsupportedCurrency},
+		{"moeda fora do Feed Registry segue pelo câmbio", "/api/price/btc/brl", true, http.StatusNotFound, codeUnsupportedCurrency},
+		{"ativo do grafo é convertido on-chain", "/api/price/btc/eth", false, http.StatusBadGateway, codeFeedUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// O nó RPC é inalcançável: o caminho on-chain falha na primeira
+			// leitura, sem consultar o câmbio.
+			client, err := ethclient.Dial("http://127.0.0.1:1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			rates := &fakeRateSource{}
+			chainlink := service.NewChainlinkService(client, map[string]config.Feed{
+				"btc": {Quote: "usd"},
+				"eth": {Quote: "usd"},
+			}, rates)
+			if err := chainlink.EnableFeedRegistry(); err != nil {
+				t.Fatal(err)
+			}
+			networks := map[string]*service.Network{
+				config.DefaultNetwork: {Name: config.DefaultNetwork, Chainlink: chainlink, Cache: service.NewPriceCache(chainlink, time.Minute)},
+			}
+
+			router := gin.New()
+			NewPriceHandler(networks, service.NewAssetService(nil), rates).RegisterRoutes(router)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if fiat := rates.currencyChecks > 0; fiat != tt.wantFiat {
+				t.Errorf("caminho fiduciário = %v, esperado %v", fiat, tt.wantFiat)
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+				t.Fatalf("resposta inválida: %v: %s", err, recorder.Body)
+			}
+			if recorder.Code != tt.wantStatus || response.Code != tt.wantCode {
+				t.Errorf("resposta = %d %s, esperado %d %s", recorder.Code, response.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+type RoundResponse struct {
+	RoundID           string `json:"roundId"`
+	PhaseID           uint16 `json:"phaseId"`
+	AggregatorRoundID uint64 `json:"aggregatorRoundId"`
+	Aggregator        string `json:"aggregator,omitempty"`
+	Answer            string `json:"answer"`
+	Decimals          uint8  `json:"decimals"`
+	Price             string `json:"price"`
+	PriceExact        string `json:"priceExact"`
+	StartedAt         int64  `json:"startedAt"`
+	UpdatedAt         int64  `json:"updatedAt"`
+	AnsweredInRound   string `json:"answeredInRound"`
+
+	FX *FXResponse `json:"fx,omitempty"`
+}
+
+type HistoryResponse struct {
+	Pair       string          `json:"pair"`
+	Currency   string          `json:"currency,omitempty"`
+	Rounds     []RoundResponse `json:"rounds"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	ImageURL   string          `json:"imageUrl"`
+	Block      *BlockResponse  `json:"block,omitempty"`
+}
+
+func (h *PriceHandler) getPriceHistory(c *gin.Context) {
+	asset := strings.ToLower(c.Param("asset"))
+
+	limit := service.DefaultHistoryLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			respondInvalid(c, "parâmetro 'limit' inválido")
+			return
+		}
+		limit = parsed
+	}
+
+	var cursor *big.Int
+	if raw := c.Query("cursor"); raw != "" {
+		parsed, ok := new(big.Int).SetString(raw, 10)
+		if !ok || parsed.Sign() <= 0 {
+			respondInvalid(c, "parâmetro 'cursor' inválido")
+			return
+		}
+		cursor = parsed
+	}
+
+	opts, ok := h.queryOptions(c)
+	if !ok {
+		return
+	}
+	precision, ok := pricePrecision(c)
+	if !ok {
+		return
+	}
+
+	chainlink := network(c).Chainlink
+	var history *service.PriceHistory
+	var err error
+	if currency := strings.ToLower(c.Query("currency")); currency != "" {
+		history, err = chainlink.GetPriceHistoryFiat(c.Request.Context(), asset, currency, limit, cursor, opts)
+	} else {
+		history, err = chainlink.GetPriceHistory(c.Request.Context(), asset, limit, cursor, opts)
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	imageURL, _ := h.assetService.GetAssetImageURL(asset)
+
+	response := HistoryResponse{
+		Pair:     history.Pair,
+		Currency: history.Currency,
+		Rounds:   make([]RoundResponse, len(history.Rounds)),
+		ImageURL: imageURL,
+		Block:    newBlockResponse(history.Block),
+	}
+	for i, round := range history.Rounds {
+		response.Rounds[i] = newRoundResponse(round, precision(asset))
+	}
+	if history.NextCursor != nil {
+		response.NextCursor = history.NextCursor.String()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func newRoundResponse(round *service.RoundData, precision int) RoundResponse {
+	response := RoundResponse{
+		RoundID:           round.RoundID.String(),
+		PhaseID:           round.PhaseID,
+		AggregatorRoundID: round.AggregatorRoundID,
+		Answer:            round.Answer.String(),
+		Decimals:          round.Decimals,
+		Price:             round.Price.FloatString(precision),
+		PriceExact:        formatExact(round.Price),
+		StartedAt:         round.StartedAt,
+		UpdatedAt:         round.UpdatedAt,
+		AnsweredInRound:   round.AnsweredInRound.String(),
+	}
+	if round.Aggregator != (common.Address{}) {
+		response.Aggregator = round.Aggregator.Hex()
+	}
+	response.FX = newFXResponse(round.FX)
+	return response
+}
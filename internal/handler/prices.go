@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// priceSelection lê os parâmetros de seleção, filtro e ordenação de
+// /api/prices.
+func priceSelection(c *gin.Context) (selection service.PriceSelection, ok bool) {
+	if raw := strings.TrimSpace(c.Query("assets")); raw != "" {
+		for _, asset := range strings.Split(strings.ToLower(raw), ",") {
+			asset = strings.TrimSpace(asset)
+			if asset == "" {
+				respondInvalid(c, "parâmetro 'assets' inválido: informe os ativos separados por vírgula")
+				return selection, false
+			}
+			selection.Assets = append(selection.Assets, asset)
+		}
+	}
+
+	selection.Category = strings.ToLower(strings.TrimSpace(c.Query("category")))
+
+	if raw := c.Query("maxAgeSeconds"); raw != "" {
+		maxAge, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || maxAge <= 0 {
+			respondInvalid(c, "parâmetro 'maxAgeSeconds' inválido: informe um número inteiro positivo")
+			return selection, false
+		}
+		selection.MaxAgeSeconds = maxAge
+	}
+
+	switch sort := service.PriceSort(c.DefaultQuery("sort", string(service.SortBySymbol))); sort {
+	case service.SortBySymbol, service.SortByPrice, service.SortByAge:
+		selection.Sort = sort
+	default:
+		respondInvalid(c, "parâmetro 'sort' inválido: use 'symbol', 'price' ou 'age'")
+		return selection, false
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		selection.Descending = true
+	default:
+		respondInvalid(c, "parâmetro 'order' inválido: use 'asc' ou 'desc'")
+		return selection, false
+	}
+
+	return selection, true
+}
+
+// getPrices lê os ativos selecionados em um único lote, do snapshot do
+// poller ou de uma leitura em bloco, e aplica os filtros e a ordenação
+// sobre o resultado.
+func (h *PriceHandler) getPrices(c *gin.Context) {
+	n := network(c)
+
+	selection, ok := priceSelection(c)
+	if !ok {
+		return
+	}
+	assets, err := n.Chainlink.SelectAssets(selection)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	currency := strings.ToLower(c.DefaultQuery("currency", config.DefaultQuote))
+	var getBatchFunc batchFunc
+	if currency == config.DefaultQuote {
+		snapshot := func(mode service.ValidationMode) (*service.PriceBatch, bool, error) {
+			return n.Poller.PricesUSD(assets, mode)
+		}
+		getBatchFunc = batchFromSnapshot(n.Poller, snapshot, func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error) {
+			return n.Cache.GetPricesUSD(ctx, assets, opts)
+		})
+	} else {
+		if err := n.Chainlink.CheckCurrency(c.Request.Context(), currency); err != nil {
+			respondError(c, err)
+			return
+		}
+		snapshot := func(mode service.ValidationMode) (*service.PriceBatch, bool, error) {
+			return n.Poller.PricesFiat(c.Request.Context(), assets, currency, mode)
+		}
+		getBatchFunc = batchFromSnapshot(n.Poller, snapshot, func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error) {
+			return n.Cache.GetPricesFiat(ctx, assets, currency, opts)
+		})
+	}
+
+	h.getAllPrices(c, currency, func(ctx context.Context, opts service.QueryOptions) (*service.PriceBatch, service.CacheStatus, error) {
+		batch, cacheStatus, err := getBatchFunc(ctx, opts)
+		if err != nil {
+			return nil, cacheStatus, err
+		}
+		return selection.Apply(batch), cacheStatus, nil
+	})
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+// streamCursor guarda o último roundId enviado de cada ativo. Ele é usado
+// como id dos eventos, para que o cliente retome a conexão pelo
+// Last-Event-ID sem receber de novo rounds que já conhece.
+type streamCursor map[string]*big.Int
+
+func parseStreamCursor(raw string) streamCursor {
+	cursor := make(streamCursor)
+	for _, part := range strings.Split(raw, ",") {
+		asset, round, found := strings.Cut(part, ":")
+		if !found {
+			continue
+		}
+		if roundID, ok := new(big.Int).SetString(round, 10); ok {
+			cursor[asset] = roundID
+		}
+	}
+	return cursor
+}
+
+func (c streamCursor) String() string {
+	parts := make([]string, 0, len(c))
+	for asset, roundID := range c {
+		parts = append(parts, asset+":"+roundID.String())
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+func streamAssets(n *service.Network, raw string) ([]string, error) {
+	available := n.Chainlink.Assets()
+	if raw == "" {
+		return available, nil
+	}
+
+	var assets []string
+	for _, asset := range strings.Split(strings.ToLower(raw), ",") {
+		asset = strings.TrimSpace(asset)
+		if !slices.Contains(available, asset) {
+			return nil, fmt.Errorf("%w: '%s'", service.ErrUnsupportedAsset, asset)
+		}
+		if !slices.Contains(assets, asset) {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+func (h *PriceHandler) streamPrices(c *gin.Context) {
+	n := network(c)
+	if n.Poller == nil {
+		abortWithError(c, http.StatusServiceUnavailable, ErrorResponse{Erro: "streaming indisponível: a atualização em segundo plano está desativada", Code: codeServiceUnavailable})
+		return
+	}
+
+	assets, err := streamAssets(n, c.Query("assets"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	precision, ok := pricePrecision(c)
+	if !ok {
+		return
+	}
+
+	currency := strings.ToLower(c.DefaultQuery("currency", "usd"))
+	if err := n.Chainlink.CheckCurrency(c.Request.Context(), currency); err != nil {
+		respondError(c, err)
+		return
+	}
+	snapshot := func(asset string, mode service.ValidationMode) (*service.PriceData, bool, error) {
+		return n.Poller.PriceFiat(c.Request.Context(), asset, currency, mode)
+	}
+
+	cursor := parseStreamCursor(c.GetHeader("Last-Event-ID"))
+	for asset := range cursor {
+		if !slices.Contains(assets, asset) {
+			delete(cursor, asset)
+		}
+	}
+
+	// O servidor limita o tempo de escrita das respostas, o que encerraria a
+	// conexão de streaming.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("não foi possível remover o prazo de escrita do streaming: %v", err)
+	}
+
+	subscription := n.Poller.Subscribe()
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	send := func(changed []string) {
+		for _, asset := range changed {
+			if !slices.Contains(assets, asset) {
+				continue
+			}
+
+			priceData, ok, err := snapshot(asset, service.ValidationLenient)
+			if !ok {
+				continue
+			}
+			if err != nil {
+				c.Render(-1, sse.Event{Event: "error", Data: gin.H{"asset": asset, "code": errorCode(err), "erro": err.Error()}})
+				continue
+			}
+			if last, found := cursor[asset]; found && last.Cmp(priceData.Round.RoundID) == 0 {
+				continue
+			}
+			cursor[asset] = priceData.Round.RoundID
+
+			imageURL, _ := h.assetService.GetAssetImageURL(asset)
+			c.Render(-1, sse.Event{Id: cursor.String(), Event: "price", Data: newPriceResponse(priceData, imageURL, precision(asset))})
+		}
+		c.Writer.Flush()
+	}
+
+	send(assets)
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-subscription.C():
+			send(subscription.Drain())
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
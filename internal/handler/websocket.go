@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsMaxSubscriptions = 20
+	wsMaxMessageSize   = 4096
+	wsWriteTimeout     = 10 * time.Second
+	wsPongTimeout      = 60 * time.Second
+	wsPingInterval     = wsPongTimeout * 9 / 10
+)
+
+// A API já libera qualquer origem via CORS, e o WebSocket segue a mesma
+// política.
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// WSPair identifica uma assinatura: um ativo cotado em uma moeda.
+type WSPair struct {
+	Asset    string `json:"asset"`
+	Currency string `json:"currency"`
+}
+
+// WSRequest é a mensagem enviada pelo cliente. Type é "subscribe",
+// "unsubscribe" ou "ping"; ID, se informado, é repetido na resposta.
+type WSRequest struct {
+	Type  string   `json:"type"`
+	ID    string   `json:"id,omitempty"`
+	Pairs []WSPair `json:"pairs,omitempty"`
+}
+
+// WSMessage é a mensagem enviada pelo servidor. Type é "subscribed",
+// "unsubscribed", "price", "error" ou "pong".
+type WSMessage struct {
+	Type          string         `json:"type"`
+	ID            string         `json:"id,omitempty"`
+	Subscriptions []WSPair       `json:"subscriptions,omitempty"`
+	Pair          *WSPair        `json:"pair,omitempty"`
+	Price         *PriceResponse `json:"price,omitempty"`
+	Erro          string         `json:"erro,omitempty"`
+	Code          string         `json:"code,omitempty"`
+}
+
+// wsSession guarda o estado de uma conexão. Ele só é acessado pela goroutine
+// de escrita; a de leitura apenas repassa as mensagens recebidas.
+type wsSession struct {
+	h       *PriceHandler
+	network *service.Network
+	conn    *websocket.Conn
+	// precision define as casas decimais dos preços enviados.
+	precision precisionFunc
+
+	// subscriptions associa cada par ao último roundId enviado.
+	subscriptions map[WSPair]*big.Int
+}
+
+func (h *PriceHandler) priceWebSocket(c *gin.Context) {
+	n := network(c)
+	if n.Poller == nil {
+		abortWithError(c, http.StatusServiceUnavailable, ErrorResponse{Erro: "websocket indisponível: a atualização em segundo plano está desativada", Code: codeServiceUnavailable})
+		return
+	}
+
+	precision, ok := pricePrecision(c)
+	if !ok {
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("falha ao abrir websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	session := &wsSession{h: h, network: n, conn: conn, precision: precision, subscriptions: make(map[WSPair]*big.Int)}
+
+	requests := make(chan WSRequest)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(done)
+		session.readRequests(requests, stop)
+	}()
+
+	session.run(c.Request.Context(), requests, done)
+}
+
+func (s *wsSession) readRequests(requests chan<- WSRequest, stop <-chan struct{}) {
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var request WSRequest
+		if err := s.conn.ReadJSON(&request); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("websocket encerrado: %v", err)
+			}
+			return
+		}
+		select {
+		case requests <- request:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *wsSession) run(ctx context.Context, requests <-chan WSRequest, done <-chan struct{}) {
+	subscription := s.network.Poller.Subscribe()
+	defer subscription.Close()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case request := <-requests:
+			err = s.handle(ctx, request)
+		case <-subscription.C():
+			err = s.sendPrices(ctx, subscription.Drain())
+		case <-ping.C:
+			err = s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+		}
+		// Um cliente que não consome as mensagens a tempo é desconectado.
+		if err != nil {
+			log.Printf("falha ao escrever no websocket: %v", err)
+			return
+		}
+	}
+}
+
+func wsError(id string, pair *WSPair, err error) WSMessage {
+	return WSMessage{Type: "error", ID: id, Pair: pair, Erro: err.Error(), Code: errorCode(err)}
+}
+
+func (s *wsSession) write(message WSMessage) error {
+	s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return s.conn.WriteJSON(message)
+}
+
+func (s *wsSession) handle(ctx context.Context, request WSRequest) error {
+	switch request.Type {
+	case "subscribe":
+		pairs, err := s.normalizePairs(ctx, request.Pairs)
+		if err != nil {
+			return s.write(wsError(request.ID, nil, err))
+		}
+
+		added := 0
+		for _, pair := range pairs {
+			if _, found := s.subscriptions[pair]; !found {
+				added++
+			}
+		}
+		if len(s.subscriptions)+added > wsMaxSubscriptions {
+			return s.write(WSMessage{Type: "error", ID: request.ID, Erro: fmt.Sprintf("limite de %d assinaturas por conexão excedido", wsMaxSubscriptions), Code: codeInvalidParameter})
+		}
+
+		for _, pair := range pairs {
+			if _, found := s.subscriptions[pair]; !found {
+				s.subscriptions[pair] = nil
+			}
+		}
+		if err := s.write(WSMessage{Type: "subscribed", ID: request.ID, Subscriptions: s.subscriptionList()}); err != nil {
+			return err
+		}
+
+		assets := make([]string, len(pairs))
+		for i, pair := range pairs {
+			assets[i] = pair.Asset
+		}
+		return s.sendPrices(ctx, assets)
+
+	case "unsubscribe":
+		pairs, err := s.normalizePairs(ctx, request.Pairs)
+		if err != nil {
+			return s.write(wsError(request.ID, nil, err))
+		}
+		for _, pair := range pairs {
+			delete(s.subscriptions, pair)
+		}
+		return s.write(WSMessage{Type: "unsubscribed", ID: request.ID, Subscriptions: s.subscriptionList()})
+
+	case "ping":
+		return s.write(WSMessage{Type: "pong", ID: request.ID})
+
+	default:
+		return s.write(WSMessage{Type: "error", ID: request.ID, Erro: fmt.Sprintf("tipo de mensagem '%s' inválido: use 'subscribe', 'unsubscribe' ou 'ping'", request.Type), Code: codeInvalidParameter})
+	}
+}
+
+func (s *wsSession) normalizePairs(ctx context.Context, pairs []WSPair) ([]WSPair, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%w: nenhum par informado", service.ErrInvalidArgument)
+	}
+
+	available := s.network.Chainlink.Assets()
+	normalized := make([]WSPair, len(pairs))
+	for i, pair := range pairs {
+		pair.Asset = strings.ToLower(pair.Asset)
+		pair.Currency = strings.ToLower(pair.Currency)
+		if pair.Currency == "" {
+			pair.Currency = "usd"
+		}
+		if !slices.Contains(available, pair.Asset) {
+			return nil, fmt.Errorf("%w: '%s'", service.ErrUnsupportedAsset, pair.Asset)
+		}
+		if err := s.network.Chainlink.CheckCurrency(ctx, pair.Currency); err != nil {
+			return nil, err
+		}
+		normalized[i] = pair
+	}
+	return normalized, nil
+}
+
+func (s *wsSession) subscriptionList() []WSPair {
+	pairs := make([]WSPair, 0, len(s.subscriptions))
+	for pair := range s.subscriptions {
+		pairs = append(pairs, pair)
+	}
+	slices.SortFunc(pairs, func(a, b WSPair) int {
+		return strings.Compare(a.Asset+"/"+a.Currency, b.Asset+"/"+b.Currency)
+	})
+	return pairs
+}
+
+// sendPrices envia o preço atual dos pares assinados dos ativos informados,
+// exceto quando o round já foi enviado.
+func (s *wsSession) sendPrices(ctx context.Context, assets []string) error {
+	for _, pair := range s.subscriptionList() {
+		if !slices.Contains(assets, pair.Asset) {
+			continue
+		}
+
+		priceData, ok, err := s.network.Poller.PriceFiat(ctx, pair.Asset, pair.Currency, service.ValidationLenient)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			if err := s.write(wsError("", &pair, err)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if last := s.subscriptions[pair]; last != nil && last.Cmp(priceData.Round.RoundID) == 0 {
+			continue
+		}
+		s.subscriptions[pair] = priceData.Round.RoundID
+
+		imageURL, _ := s.h.assetService.GetAssetImageURL(pair.Asset)
+		response := newPriceResponse(priceData, imageURL, s.precision(pair.Asset))
+		if err := s.write(WSMessage{Type: "price", Pair: &pair, Price: &response}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,26 +2,30 @@ package service
 
 import (
 	"fmt"
+	"sync"
 )
 
-var assetImageURLs = map[string]string{
-	"1inch": "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/1inch-logo.png?raw=true",
-	"link":  "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/link-logo.png?raw=true",
-	"btc":   "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/btc-logo.png?raw=true",
-	"eth":   "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/ether-logo.png?raw=true",
-	"paxg":  "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/paxg-logo.png?raw=true",
-	"stx":   "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/stx-logo.png?raw=true",
-	"uni":   "https://github.com/dev-araujo/chainlink-price-feed/blob/main/assets/tokens/uni-logo.png?raw=true",
+type AssetService struct {
+	mu        sync.RWMutex
+	imageURLs map[string]string
 }
 
-type AssetService struct{}
+func NewAssetService(imageURLs map[string]string) *AssetService {
+	return &AssetService{imageURLs: imageURLs}
+}
 
-func NewAssetService() *AssetService {
-	return &AssetService{}
+// SetImageURLs substitui as imagens dos ativos quando o registro de feeds é
+// recarregado.
+func (s *AssetService) SetImageURLs(imageURLs map[string]string) {
+	s.mu.Lock()
+	s.imageURLs = imageURLs
+	s.mu.Unlock()
 }
 
 func (s *AssetService) GetAssetImageURL(asset string) (string, error) {
-	url, found := assetImageURLs[asset]
+	s.mu.RLock()
+	url, found := s.imageURLs[asset]
+	s.mu.RUnlock()
 	if !found {
 		return "", fmt.Errorf("imagem para o ativo '%s' não encontrada", asset)
 	}
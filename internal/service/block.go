@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type BlockRef struct {
+	Number    *big.Int
+	Hash      common.Hash
+	Timestamp uint64
+
+	byHash bool
+}
+
+// QueryOptions controla como as leituras on-chain são feitas. O valor zero
+// lê o estado do bloco mais recente.
+type QueryOptions struct {
+	Block      *BlockRef
+	Validation ValidationMode
+}
+
+// referenceTime é o instante usado para calcular a idade de um preço: o
+// timestamp do bloco fixado ou, na falta dele, o momento atual.
+func (o QueryOptions) referenceTime() time.Time {
+	if o.Block != nil {
+		return time.Unix(int64(o.Block.Timestamp), 0)
+	}
+	return time.Now()
+}
+
+func (o QueryOptions) callOpts(ctx context.Context) *bind.CallOpts {
+	callOpts := &bind.CallOpts{Context: ctx}
+	if o.Block != nil {
+		if o.Block.byHash {
+			callOpts.BlockHash = o.Block.Hash
+		} else {
+			callOpts.BlockNumber = o.Block.Number
+		}
+	}
+	return callOpts
+}
+
+// ResolveBlock converte "latest", um número de bloco (decimal) ou um hash de
+// bloco (0x + 64 dígitos hexadecimais) no cabeçalho correspondente, para que
+// a leitura possa ser fixada nele.
+func (s *ChainlinkService) ResolveBlock(ctx context.Context, block string) (*BlockRef, error) {
+	var (
+		header *types.Header
+		err    error
+		byHash bool
+	)
+
+	switch {
+	case block == "latest":
+		header, err = s.client.HeaderByNumber(ctx, nil)
+	case strings.HasPrefix(block, "0x") && len(block) == 2+2*common.HashLength:
+		byHash = true
+		header, err = s.client.HeaderByHash(ctx, common.HexToHash(block))
+	default:
+		number, ok := new(big.Int).SetString(block, 10)
+		if !ok || number.Sign() < 0 {
+			return nil, classify(ErrInvalidArgument, fmt.Errorf("bloco '%s' inválido: use um número, um hash ou 'latest'", block))
+		}
+		header, err = s.client.HeaderByNumber(ctx, number)
+	}
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, classify(ErrNotFound, fmt.Errorf("bloco '%s' não encontrado", block))
+		}
+		return nil, upstreamError(fmt.Errorf("falha ao buscar o bloco '%s': %w", block, err))
+	}
+
+	return &BlockRef{
+		Number:    header.Number,
+		Hash:      header.Hash(),
+		Timestamp: header.Time,
+		byHash:    byHash,
+	}, nil
+}
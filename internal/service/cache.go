@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type CacheStatus string
+
+const (
+	CacheHit  CacheStatus = "HIT"
+	CacheMiss CacheStatus = "MISS"
+	// CacheStale indica que a origem falhou e o último valor conhecido foi
+	// servido no lugar do erro.
+	CacheStale CacheStatus = "STALE"
+)
+
+type cacheEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// ttlCache é um cache em memória com expiração por entrada. Buscas
+// concorrentes pela mesma chave são agrupadas em uma única chamada à origem.
+type ttlCache[T any] struct {
+	mu      sync.RWMutex
+	entries map[string]cacheEntry[T]
+	group   singleflight.Group
+
+	// maxEntries, quando positivo, limita o cache para chaves de alta
+	// cardinalidade: ao atingi-lo, as entradas expiradas são descartadas e,
+	// se ainda assim não houver espaço, o cache é esvaziado.
+	maxEntries int
+}
+
+func newTTLCache[T any]() *ttlCache[T] {
+	return &ttlCache[T]{entries: make(map[string]cacheEntry[T])}
+}
+
+func (c *ttlCache[T]) reset() {
+	c.mu.Lock()
+	clear(c.entries)
+	c.mu.Unlock()
+}
+
+// get retorna o valor em cache enquanto ele não expirar. Se a origem falhar
+// com um erro para o qual canServeStale retorna true, o último valor
+// conhecido é retornado com CacheStale.
+func (c *ttlCache[T]) get(key string, ttl time.Duration, load func() (T, error), canServeStale func(error) bool) (T, CacheStatus, error) {
+	return c.getWithTTL(key, func() (T, time.Duration, error) {
+		value, err := load()
+		return value, ttl, err
+	}, canServeStale)
+}
+
+// getWithTTL é como get, mas o TTL é definido por load junto com o valor.
+func (c *ttlCache[T]) getWithTTL(key string, load func() (T, time.Duration, error), canServeStale func(error) bool) (T, CacheStatus, error) {
+	c.mu.RLock()
+	entry, found := c.entries[key]
+	c.mu.RUnlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.value, CacheHit, nil
+	}
+
+	loaded, err, _ := c.group.Do(key, func() (interface{}, error) {
+		value, ttl, err := load()
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.makeRoom()
+		c.entries[key] = cacheEntry[T]{value: value, expiresAt: time.Now().Add(ttl)}
+		c.mu.Unlock()
+		return value, nil
+	})
+	if err != nil {
+		if found && canServeStale(err) {
+			return entry.value, CacheStale, nil
+		}
+		var zero T
+		return zero, CacheMiss, err
+	}
+
+	return loaded.(T), CacheMiss, nil
+}
+
+func (c *ttlCache[T]) makeRoom() {
+	if c.maxEntries <= 0 || len(c.entries) < c.maxEntries {
+		return
+	}
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		clear(c.entries)
+	}
+}
+
+// Falhas de validação refletem o estado do feed, não uma indisponibilidade
+// da origem, e por isso não são mascaradas com o valor antigo.
+func isUpstreamError(err error) bool {
+	var validationErr *FeedValidationError
+	return !errors.As(err, &validationErr)
+}
+
+// PriceCache envolve o ChainlinkService com um cache por feed. Leituras
+// fixadas em um bloco não passam pelo cache.
+type PriceCache struct {
+	chainlinkService *ChainlinkService
+	defaultTTL       time.Duration
+
+	prices     *ttlCache[*PriceData]
+	batches    *ttlCache[*PriceBatch]
+	crossRates *ttlCache[*CrossRate]
+}
+
+// maxBatchEntries limita o cache de lotes, indexado pelo conjunto de ativos
+// da requisição.
+const maxBatchEntries = 1024
+
+func NewPriceCache(chainlinkService *ChainlinkService, defaultTTL time.Duration) *PriceCache {
+	batches := newTTLCache[*PriceBatch]()
+	batches.maxEntries = maxBatchEntries
+	return &PriceCache{
+		chainlinkService: chainlinkService,
+		defaultTTL:       defaultTTL,
+		prices:           newTTLCache[*PriceData](),
+		batches:          batches,
+		crossRates:       newTTLCache[*CrossRate](),
+	}
+}
+
+func (c *PriceCache) ttlFor(asset string) time.Duration {
+	if feed, _ := c.chainlinkService.feed(asset); feed.CacheTTL > 0 {
+		return feed.CacheTTL
+	}
+	return c.defaultTTL
+}
+
+// Reset descarta os valores em cache, usado quando o conjunto de feeds muda.
+func (c *PriceCache) Reset() {
+	c.prices.reset()
+	c.batches.reset()
+	c.crossRates.reset()
+}
+
+func cacheKey(kind string, opts QueryOptions, parts ...string) string {
+	return fmt.Sprintf("%s:%d:%s", kind, opts.Validation, strings.Join(parts, ","))
+}
+
+const cacheFetchTimeout = 10 * time.Second
+
+// A busca é compartilhada por todas as requisições agrupadas, então não pode
+// ser cancelada quando a requisição que a disparou termina.
+func detachedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), cacheFetchTimeout)
+}
+
+func (c *PriceCache) getPrice(ctx context.Context, currency, asset string, opts QueryOptions, fetch func(ctx context.Context, asset string, opts QueryOptions) (*PriceData, error)) (*PriceData, CacheStatus, error) {
+	if opts.Block != nil {
+		priceData, err := fetch(ctx, asset, opts)
+		return priceData, CacheMiss, err
+	}
+
+	return c.prices.get(cacheKey(currency, opts, asset), c.ttlFor(asset), func() (*PriceData, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		return fetch(ctx, asset, opts)
+	}, isUpstreamError)
+}
+
+func (c *PriceCache) GetPriceUSD(ctx context.Context, asset string, opts QueryOptions) (*PriceData, CacheStatus, error) {
+	return c.getPrice(ctx, "usd", asset, opts, c.chainlinkService.GetPriceUSD)
+}
+
+func (c *PriceCache) GetPriceFiat(ctx context.Context, asset, currency string, opts QueryOptions) (*PriceData, CacheStatus, error) {
+	return c.getPrice(ctx, strings.ToLower(currency), asset, opts, func(ctx context.Context, asset string, opts QueryOptions) (*PriceData, error) {
+		return c.chainlinkService.GetPriceFiat(ctx, asset, currency, opts)
+	})
+}
+
+// getPrices armazena o lote inteiro, com o menor TTL entre os feeds
+// envolvidos, para preservar a leitura de todos no mesmo bloco.
+func (c *PriceCache) getPrices(ctx context.Context, currency string, assets []string, opts QueryOptions, fetch func(ctx context.Context, assets []string, opts QueryOptions) (*PriceBatch, error)) (*PriceBatch, CacheStatus, error) {
+	if opts.Block != nil {
+		batch, err := fetch(ctx, assets, opts)
+		return batch, CacheMiss, err
+	}
+
+	ttl := c.defaultTTL
+	for _, asset := range assets {
+		if assetTTL := c.ttlFor(asset); assetTTL < ttl {
+			ttl = assetTTL
+		}
+	}
+
+	return c.batches.get(cacheKey(currency, opts, assets...), ttl, func() (*PriceBatch, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		return fetch(ctx, assets, opts)
+	}, isUpstreamError)
+}
+
+func (c *PriceCache) GetPricesUSD(ctx context.Context, assets []string, opts QueryOptions) (*PriceBatch, CacheStatus, error) {
+	return c.getPrices(ctx, "usd", assets, opts, c.chainlinkService.GetPricesUSD)
+}
+
+func (c *PriceCache) GetPricesFiat(ctx context.Context, assets []string, currency string, opts QueryOptions) (*PriceBatch, CacheStatus, error) {
+	return c.getPrices(ctx, strings.ToLower(currency), assets, opts, func(ctx context.Context, assets []string, opts QueryOptions) (*PriceBatch, error) {
+		return c.chainlinkService.GetPricesFiat(ctx, assets, currency, opts)
+	})
+}
+
+func (c *PriceCache) GetAllPricesUSD(ctx context.Context, opts QueryOptions) (*PriceBatch, CacheStatus, error) {
+	return c.GetPricesUSD(ctx, c.chainlinkService.Assets(), opts)
+}
+
+func (c *PriceCache) GetAllPricesFiat(ctx context.Context, currency string, opts QueryOptions) (*PriceBatch, CacheStatus, error) {
+	return c.GetPricesFiat(ctx, c.chainlinkService.Assets(), currency, opts)
+}
+
+// GetCrossRate armazena a conversão com o menor TTL entre os feeds do
+// caminho.
+func (c *PriceCache) GetCrossRate(ctx context.Context, base, quote string, opts QueryOptions) (*CrossRate, CacheStatus, error) {
+	if opts.Block != nil {
+		crossRate, err := c.chainlinkService.GetCrossRate(ctx, base, quote, opts)
+		return crossRate, CacheMiss, err
+	}
+
+	path, err := c.chainlinkService.conversionPath(base, quote)
+	if err != nil {
+		return nil, CacheMiss, err
+	}
+	ttl := c.defaultTTL
+	for _, edge := range path {
+		ttl = min(ttl, c.ttlFor(edge.Feed))
+	}
+
+	return c.crossRates.get(cacheKey("cross", opts, base, quote), ttl, func() (*CrossRate, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		return c.chainlinkService.GetCrossRate(ctx, base, quote, opts)
+	}, isUpstreamError)
+}
+
+// CachedExchangeService envolve o ExchangeService, evitando uma chamada aos
+// provedores de câmbio a cada preço convertido.
+type CachedExchangeService struct {
+	exchangeService *ExchangeService
+	ttl             time.Duration
+	rates           *ttlCache[*FXRate]
+	historicalRates *ttlCache[*FXRate]
+	currencies      *ttlCache[map[string]string]
+}
+
+const (
+	// currenciesTTL é o TTL da lista de moedas suportadas, que raramente
+	// muda.
+	currenciesTTL = 24 * time.Hour
+	// maxFXCacheTTL limita o cache pela próxima publicação, que pode estar a
+	// dias de distância em fins de semana e feriados.
+	maxFXCacheTTL = 12 * time.Hour
+	// settledFXAge é a idade a partir da qual uma taxa histórica já foi
+	// publicada por todos os provedores e não muda mais.
+	settledFXAge = 48 * time.Hour
+	// maxHistoricalFXEntries limita o cache de taxas históricas, indexado
+	// pela data consultada.
+	maxHistoricalFXEntries = 4096
+)
+
+func NewCachedExchangeService(exchangeService *ExchangeService, ttl time.Duration) *CachedExchangeService {
+	historicalRates := newTTLCache[*FXRate]()
+	historicalRates.maxEntries = maxHistoricalFXEntries
+	return &CachedExchangeService{
+		exchangeService: exchangeService,
+		ttl:             ttl,
+		rates:           newTTLCache[*FXRate](),
+		historicalRates: historicalRates,
+		currencies:      newTTLCache[map[string]string](),
+	}
+}
+
+// GetRate mantém a taxa em cache por pelo menos o TTL configurado e, quando
+// o provedor informa a próxima publicação, até ela, já que antes disso a
+// taxa não muda.
+func (s *CachedExchangeService) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	rate, _, err := s.rates.getWithTTL(from+"/"+to, func() (*FXRate, time.Duration, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		rate, err := s.exchangeService.GetRate(ctx, from, to)
+		if err != nil {
+			return nil, 0, err
+		}
+		return rate, max(s.ttl, min(time.Until(rate.NextUpdate), maxFXCacheTTL)), nil
+	}, isUpstreamError)
+	return rate, err
+}
+
+// GetRateAt mantém por maxFXCacheTTL as taxas de instantes antigos o
+// bastante para não mudarem; as recentes seguem o TTL configurado. As taxas
+// são indexadas pela data em UTC, já que os provedores publicam uma taxa por
+// dia: os rounds de um histórico no mesmo dia usam uma única consulta.
+func (s *CachedExchangeService) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	key := fmt.Sprintf("%s/%s@%s", from, to, at.UTC().Format(time.DateOnly))
+	rate, _, err := s.historicalRates.getWithTTL(key, func() (*FXRate, time.Duration, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		rate, err := s.exchangeService.GetRateAt(ctx, from, to, at)
+		if err != nil {
+			return nil, 0, err
+		}
+		if time.Since(at) > settledFXAge {
+			return rate, maxFXCacheTTL, nil
+		}
+		return rate, s.ttl, nil
+	}, isUpstreamError)
+	return rate, err
+}
+
+func (s *CachedExchangeService) Currencies(ctx context.Context) (map[string]string, error) {
+	currencies, _, err := s.currencies.get("currencies", currenciesTTL, func() (map[string]string, error) {
+		ctx, cancel := detachedContext(ctx)
+		defer cancel()
+		return s.exchangeService.Currencies(ctx)
+	}, isUpstreamError)
+	return currencies, err
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// countingRateProvider conta as consultas de taxas históricas.
+type countingRateProvider struct {
+	calls int
+}
+
+func (p *countingRateProvider) Name() string { return "teste" }
+
+func (p *countingRateProvider) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	return &FXRate{From: from, To: to, Rate: big.NewRat(5, 1)}, nil
+}
+
+func (p *countingRateProvider) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	p.calls++
+	return &FXRate{From: from, To: to, Rate: big.NewRat(5, 1)}, nil
+}
+
+func (p *countingRateProvider) Currencies(ctx context.Context) (map[string]string, error) {
+	return map[string]string{"USD": "United States Dollar", "BRL": "Real"}, nil
+}
+
+func TestCachedExchangeServiceGetRateAt(t *testing.T) {
+	provider := &countingRateProvider{}
+	rates := NewCachedExchangeService(NewExchangeService(provider), time.Minute)
+
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	instants := []time.Time{
+		day.Add(time.Hour),
+		day.Add(13*time.Hour + 27*time.Second),
+		day.Add(23*time.Hour + 59*time.Minute),
+		day.Add(2 * time.Hour).In(time.FixedZone("BRT", -3*60*60)),
+		day.Add(24 * time.Hour),
+	}
+	for _, at := range instants {
+		if _, err := rates.GetRateAt(context.Background(), "usd", "brl", at); err != nil {
+			t.Fatalf("GetRateAt(%s) erro: %v", at, err)
+		}
+	}
+
+	if provider.calls != 2 {
+		t.Errorf("provedor consultado %d vezes, esperado 2: uma por dia", provider.calls)
+	}
+}
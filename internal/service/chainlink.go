@@ -2,132 +2,415 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dev-araujo/chainlink-price-feed/contracts"
 	"github.com/dev-araujo/chainlink-price-feed/internal/config"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"golang.org/x/sync/errgroup"
 )
 
 type PriceData struct {
-	Pair      string
-	Price     *big.Float
+	Pair string
+	// Currency é a moeda em que Price está cotado, em maiúsculas.
+	Currency  string
+	Price     *big.Rat
 	Timestamp int64
+	Round     *RoundData
+	Block     *BlockRef
+
+	Stale      bool
+	AgeSeconds int64
+	Warning    string
+
+	Snapshot *SnapshotInfo
+	// FX é a taxa usada para converter o preço de USD para Currency.
+	FX *FXRate
 }
 
 type ChainlinkService struct {
 	client          *ethclient.Client
-	contractAddrs   map[string]string
-	exchangeService *ExchangeService
+	feeds           atomic.Pointer[map[string]config.Feed]
+	feedCache       *feedCache
+	multicall       *multicallReader
+	feedRegistry    *contracts.FeedRegistry
+	exchangeService RateSource
 }
 
-func NewChainlinkService(client *ethclient.Client, exchangeService *ExchangeService) *ChainlinkService {
-	return &ChainlinkService{
+func NewChainlinkService(client *ethclient.Client, feeds map[string]config.Feed, exchangeService RateSource) *ChainlinkService {
+	multicall, err := newMulticallReader(client)
+	if err != nil {
+		log.Printf("Multicall3 desabilitado: %v", err)
+	}
+
+	s := &ChainlinkService{
 		client:          client,
-		contractAddrs:   config.ContractAddresses,
+		feedCache:       newFeedCache(),
+		multicall:       multicall,
 		exchangeService: exchangeService,
 	}
+	s.feeds.Store(&feeds)
+	return s
 }
 
-func (s *ChainlinkService) GetPriceUSD(ctx context.Context, asset string) (*PriceData, error) {
-	return s.fetchPriceFromChainlink(ctx, asset)
+func (s *ChainlinkService) GetPriceUSD(ctx context.Context, asset string, opts QueryOptions) (*PriceData, error) {
+	if err := s.checkUSDQuote(asset); err != nil {
+		return nil, err
+	}
+	return s.fetchPriceFromChainlink(ctx, asset, opts)
 }
 
-func (s *ChainlinkService) GetPriceBRL(ctx context.Context, asset string) (*PriceData, error) {
-	assetPriceData, err := s.fetchPriceFromChainlink(ctx, asset)
+// GetPriceFiat retorna o preço do ativo em USD convertido para a moeda
+// fiduciária informada (código ISO 4217). Em leituras fixadas em um bloco, a
+// taxa é a que estava em vigor no updatedAt do round.
+func (s *ChainlinkService) GetPriceFiat(ctx context.Context, asset, currency string, opts QueryOptions) (*PriceData, error) {
+	if err := s.checkUSDQuote(asset); err != nil {
+		return nil, err
+	}
+	if err := s.CheckCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	assetPriceData, err := s.fetchPriceFromChainlink(ctx, asset, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	brlRate, err := s.exchangeService.GetBRLRate()
+	var rate *FXRate
+	if opts.Block == nil {
+		rate, err = s.fiatRate(ctx, currency)
+	} else {
+		rate, err = s.fiatRateAt(ctx, currency, assetPriceData.Timestamp)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("não foi possível obter a taxa de câmbio do BRL: %w", err)
+		return nil, err
 	}
 
-	priceInBRL := new(big.Float).Mul(assetPriceData.Price, brlRate)
+	return convertToCurrency(asset, assetPriceData, currency, rate), nil
+}
 
-	return &PriceData{
-		Pair:      fmt.Sprintf("%s/BRL", strings.ToUpper(asset)),
-		Price:     priceInBRL,
-		Timestamp: assetPriceData.Timestamp,
-	}, nil
+// CheckCurrency confere se a moeda fiduciária é suportada.
+func (s *ChainlinkService) CheckCurrency(ctx context.Context, currency string) error {
+	return checkCurrency(ctx, s.exchangeService, currency)
 }
 
-func (s *ChainlinkService) fetchPriceFromChainlink(ctx context.Context, asset string) (*PriceData, error) {
-	addressHex, ok := s.contractAddrs[asset]
-	if !ok {
-		return nil, fmt.Errorf("ativo '%s' não suportado", asset)
+func (s *ChainlinkService) fiatRate(ctx context.Context, currency string) (*FXRate, error) {
+	if err := checkCurrency(ctx, s.exchangeService, currency); err != nil {
+		return nil, err
+	}
+	rate, err := s.exchangeService.GetRate(ctx, config.DefaultQuote, currency)
+	if err != nil {
+		return nil, fmt.Errorf("não foi possível obter a taxa de câmbio do %s: %w", strings.ToUpper(currency), err)
 	}
+	return rate, nil
+}
 
-	address := common.HexToAddress(addressHex)
-	priceFeed, err := contracts.NewAggregatorV3Interface(address, s.client)
+// fiatRateAt retorna a taxa USD→moeda em vigor no instante (unix) informado.
+func (s *ChainlinkService) fiatRateAt(ctx context.Context, currency string, timestamp int64) (*FXRate, error) {
+	if err := checkCurrency(ctx, s.exchangeService, currency); err != nil {
+		return nil, err
+	}
+	at := time.Unix(timestamp, 0)
+	rate, err := s.exchangeService.GetRateAt(ctx, config.DefaultQuote, currency, at)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao instanciar contrato para %s: %w", asset, err)
+		return nil, fmt.Errorf("não foi possível obter a taxa de câmbio do %s em %s: %w", strings.ToUpper(currency), at.UTC().Format(time.RFC3339), err)
 	}
+	return rate, nil
+}
 
-	callOpts := &bind.CallOpts{Context: ctx}
+// maxConcurrentFXLookups limita as consultas simultâneas aos provedores de
+// câmbio ao converter vários preços históricos.
+const maxConcurrentFXLookups = 4
 
-	decimals, err := priceFeed.Decimals(callOpts)
+// fiatRatesAt busca em paralelo a taxa em vigor em cada instante, consultando
+// uma única vez os instantes repetidos.
+func (s *ChainlinkService) fiatRatesAt(ctx context.Context, currency string, timestamps []int64) ([]*FXRate, error) {
+	unique := make(map[int64]*FXRate)
+	for _, timestamp := range timestamps {
+		unique[timestamp] = nil
+	}
+
+	var mu sync.Mutex
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentFXLookups)
+	for timestamp := range unique {
+		g.Go(func() error {
+			rate, err := s.fiatRateAt(ctx, currency, timestamp)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			unique[timestamp] = rate
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	rates := make([]*FXRate, len(timestamps))
+	for i, timestamp := range timestamps {
+		rates[i] = unique[timestamp]
+	}
+	return rates, nil
+}
+
+func convertToCurrency(asset string, assetPriceData *PriceData, currency string, rate *FXRate) *PriceData {
+	converted := *assetPriceData
+	converted.Currency = strings.ToUpper(currency)
+	converted.Pair = fmt.Sprintf("%s/%s", strings.ToUpper(asset), converted.Currency)
+	converted.Price = new(big.Rat).Mul(assetPriceData.Price, rate.Rate)
+	if rate.From != rate.To {
+		converted.FX = rate
+	}
+	return &converted
+}
+
+// scalePrice converte a resposta inteira do feed no preço exato,
+// answer / 10^decimals.
+func scalePrice(answer *big.Int, decimals uint8) *big.Rat {
+	return new(big.Rat).SetFrac(answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+}
+
+func (s *ChainlinkService) pairName(key string) string {
+	feed, _ := s.feed(key)
+	return fmt.Sprintf("%s/%s", strings.ToUpper(config.FeedAsset(key)), strings.ToUpper(feed.Quote))
+}
+
+func (s *ChainlinkService) fetchPriceFromChainlink(ctx context.Context, asset string, opts QueryOptions) (*PriceData, error) {
+	handle, err := s.feedHandle(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	latestRoundData, err := s.latestRoundData(opts.callOpts(ctx), handle)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao buscar decimais para %s: %w", asset, err)
+		return nil, upstreamError(fmt.Errorf("falha ao buscar dados para %s: %w", asset, err))
 	}
 
-	latestRoundData, err := priceFeed.LatestRoundData(callOpts)
+	handle, err = s.checkPhase(ctx, asset, handle, latestRoundData.RoundId)
 	if err != nil {
-		return nil, fmt.Errorf("falha ao buscar dados para %s: %w", asset, err)
+		return nil, err
 	}
 
-	price := new(big.Float).SetInt(latestRoundData.Answer)
-	price.Quo(price, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	round := newRoundData(latestRoundData.RoundId, latestRoundData.Answer, latestRoundData.StartedAt, latestRoundData.UpdatedAt, latestRoundData.AnsweredInRound, handle.decimals)
+
+	return s.newPriceData(asset, round, opts)
+}
 
-	pairName := fmt.Sprintf("%s/USD", strings.ToUpper(asset))
-	if asset == "brl" {
-		pairName = "BRL/USD"
+func (s *ChainlinkService) newUSDPriceData(asset string, round *RoundData, opts QueryOptions) (*PriceData, error) {
+	if err := s.checkUSDQuote(asset); err != nil {
+		return nil, err
 	}
+	return s.newPriceData(asset, round, opts)
+}
 
-	return &PriceData{
-		Pair:      pairName,
-		Price:     price,
-		Timestamp: latestRoundData.UpdatedAt.Int64(),
-	}, nil
+// newPriceData monta o preço de um feed, cotado na moeda do próprio feed.
+func (s *ChainlinkService) newPriceData(asset string, round *RoundData, opts QueryOptions) (*PriceData, error) {
+	feed, _ := s.feed(asset)
+	priceData := &PriceData{
+		Pair:      s.pairName(asset),
+		Currency:  strings.ToUpper(feed.Quote),
+		Price:     round.Price,
+		Timestamp: round.UpdatedAt,
+		Round:     round,
+		Block:     opts.Block,
+	}
+
+	if err := s.applyValidation(asset, priceData, opts.referenceTime(), opts.Validation); err != nil {
+		return nil, err
+	}
+
+	return priceData, nil
 }
 
-func (s *ChainlinkService) fetchAllPrices(priceFetcher func(ctx context.Context, asset string) (*PriceData, error)) ([]*PriceData, error) {
-	prices := make([]*PriceData, 0, len(s.contractAddrs))
-	var mu sync.Mutex
-	g, ctx := errgroup.WithContext(context.Background())
+// PriceBatch reúne os preços de vários feeds lidos no mesmo bloco.
+type PriceBatch struct {
+	Block *BlockRef
+	// Assets traz o ativo de cada posição de Prices.
+	Assets   []string
+	Prices   []*PriceData
+	Snapshot *SnapshotInfo
+	// Errors traz os ativos que não puderam ser lidos, que ficam de fora de
+	// Assets e Prices.
+	Errors []*AssetError
+}
 
-	for asset := range s.contractAddrs {
-		asset := asset
-		g.Go(func() error {
-			priceData, err := priceFetcher(ctx, asset)
+// AssetError é a falha de um ativo dentro de um lote.
+type AssetError struct {
+	Asset string
+	Err   error
+}
+
+func (e *AssetError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AssetError) Unwrap() error {
+	return e.Err
+}
+
+// Err combina as falhas do lote, retornando nil quando todos os ativos foram
+// lidos.
+func (b *PriceBatch) Err() error {
+	errs := make([]error, len(b.Errors))
+	for i, assetErr := range b.Errors {
+		errs[i] = assetErr
+	}
+	return errors.Join(errs...)
+}
+
+// newPriceBatch separa os preços lidos das falhas. Quando nenhum ativo pôde
+// ser lido, a falha é da requisição inteira.
+func newPriceBatch(block *BlockRef, assets []string, prices []*PriceData, errs []error) (*PriceBatch, error) {
+	batch := &PriceBatch{Block: block}
+	for i, asset := range assets {
+		if errs[i] != nil {
+			batch.Errors = append(batch.Errors, &AssetError{Asset: asset, Err: errs[i]})
+			continue
+		}
+		batch.Assets = append(batch.Assets, asset)
+		batch.Prices = append(batch.Prices, prices[i])
+	}
+
+	if len(batch.Prices) == 0 && len(batch.Errors) > 0 {
+		return nil, batch.Err()
+	}
+	return batch, nil
+}
+
+// Assets retorna, em ordem alfabética, os ativos com feed cotado em USD, que
+// são os servidos pelas rotas de preço.
+func (s *ChainlinkService) Assets() []string {
+	feeds := s.priceFeeds()
+	assets := make([]string, 0, len(feeds))
+	for asset := range feeds {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	return assets
+}
+
+// fetchPrices resolve o bloco uma única vez (quando a leitura não está
+// fixada) e lê todos os feeds nele, para que o conjunto seja consistente.
+// A leitura usa um único eth_call via Multicall3 quando disponível, com
+// chamadas paralelas como alternativa. A falha de um feed não impede a
+// leitura dos demais e é informada em PriceBatch.Errors.
+func (s *ChainlinkService) fetchPrices(ctx context.Context, assets []string, opts QueryOptions) (*PriceBatch, error) {
+	if opts.Block == nil {
+		block, err := s.ResolveBlock(ctx, "latest")
+		if err != nil {
+			return nil, err
+		}
+		opts.Block = block
+	}
+
+	if s.multicall != nil && s.multicall.isAvailable(ctx) {
+		prices, errs, err := s.fetchPricesMulticall(ctx, assets, opts)
+		if !errors.Is(err, errMulticallFailed) {
 			if err != nil {
-				return fmt.Errorf("falha ao buscar preço para %s: %w", asset, err)
+				return nil, err
 			}
-			mu.Lock()
-			prices = append(prices, priceData)
-			mu.Unlock()
-			return nil
-		})
+			return newPriceBatch(opts.Block, assets, prices, errs)
+		}
+		log.Printf("Multicall3 falhou, usando chamadas paralelas: %v", err)
 	}
 
-	if err := g.Wait(); err != nil {
+	prices, errs := s.fetchPricesParallel(ctx, assets, opts)
+	return newPriceBatch(opts.Block, assets, prices, errs)
+}
+
+func (s *ChainlinkService) fetchPricesParallel(ctx context.Context, assets []string, opts QueryOptions) ([]*PriceData, []error) {
+	prices := make([]*PriceData, len(assets))
+	errs := make([]error, len(assets))
+
+	var wg sync.WaitGroup
+	for i, asset := range assets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			priceData, err := s.fetchPriceFromChainlink(ctx, asset, opts)
+			if err != nil {
+				errs[i] = fmt.Errorf("falha ao buscar preço para %s: %w", asset, err)
+				return
+			}
+			prices[i] = priceData
+		}()
+	}
+	wg.Wait()
+
+	return prices, errs
+}
+
+func (s *ChainlinkService) checkUSDQuotes(assets []string) error {
+	for _, asset := range assets {
+		if err := s.checkUSDQuote(asset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ChainlinkService) GetPricesUSD(ctx context.Context, assets []string, opts QueryOptions) (*PriceBatch, error) {
+	if err := s.checkUSDQuotes(assets); err != nil {
+		return nil, err
+	}
+	return s.fetchPrices(ctx, assets, opts)
+}
+
+// GetPricesFiat converte os preços como GetPriceFiat. Em leituras fixadas em
+// um bloco, cada preço usa a taxa em vigor no updatedAt do seu round.
+func (s *ChainlinkService) GetPricesFiat(ctx context.Context, assets []string, currency string, opts QueryOptions) (*PriceBatch, error) {
+	if err := s.checkUSDQuotes(assets); err != nil {
+		return nil, err
+	}
+	if err := s.CheckCurrency(ctx, currency); err != nil {
 		return nil, err
 	}
 
-	return prices, nil
+	batch, err := s.fetchPrices(ctx, assets, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*FXRate, len(batch.Prices))
+	if opts.Block == nil {
+		rate, err := s.fiatRate(ctx, currency)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rates {
+			rates[i] = rate
+		}
+	} else {
+		timestamps := make([]int64, len(batch.Prices))
+		for i, priceData := range batch.Prices {
+			timestamps[i] = priceData.Timestamp
+		}
+		if rates, err = s.fiatRatesAt(ctx, currency, timestamps); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, priceData := range batch.Prices {
+		batch.Prices[i] = convertToCurrency(batch.Assets[i], priceData, currency, rates[i])
+	}
+	return batch, nil
 }
 
-func (s *ChainlinkService) GetAllPricesUSD() ([]*PriceData, error) {
-	return s.fetchAllPrices(s.GetPriceUSD)
+func (s *ChainlinkService) GetAllPricesUSD(ctx context.Context, opts QueryOptions) (*PriceBatch, error) {
+	return s.GetPricesUSD(ctx, s.Assets(), opts)
 }
 
-func (s *ChainlinkService) GetAllPricesBRL() ([]*PriceData, error) {
-	return s.fetchAllPrices(s.GetPriceBRL)
+func (s *ChainlinkService) GetAllPricesFiat(ctx context.Context, currency string, opts QueryOptions) (*PriceBatch, error) {
+	return s.GetPricesFiat(ctx, s.Assets(), currency, opts)
 }
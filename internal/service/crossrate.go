@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+var ErrNoConversionPath = errors.New("nenhuma conversão disponível")
+
+// conversionEdge liga uma moeda a outra por um feed. Inverse indica que o
+// feed cota To em From, e por isso seu preço é usado invertido.
+type conversionEdge struct {
+	From    string
+	To      string
+	Feed    string
+	Inverse bool
+}
+
+// CrossRateLeg é um trecho da conversão, com a leitura do feed usado.
+type CrossRateLeg struct {
+	From    string
+	To      string
+	Inverse bool
+	Price   *PriceData
+}
+
+// Rate retorna a taxa exata do trecho, de From para To.
+func (l *CrossRateLeg) Rate() *big.Rat {
+	rate := scalePrice(l.Price.Round.Answer, l.Price.Round.Decimals)
+	if l.Inverse {
+		return rate.Inv(rate)
+	}
+	return rate
+}
+
+// CrossRate é a cotação entre dois ativos quaisquer, obtida pelo produto das
+// taxas de cada trecho do caminho mais curto entre eles.
+type CrossRate struct {
+	Base  string
+	Quote string
+	Rate  *big.Rat
+	Legs  []*CrossRateLeg
+	Block *BlockRef
+
+	// Timestamp é o updatedAt mais antigo entre os trechos, e Stale indica
+	// que algum deles não passou na validação.
+	Timestamp int64
+	Stale     bool
+}
+
+func (r *CrossRate) Pair() string {
+	return fmt.Sprintf("%s/%s", strings.ToUpper(r.Base), strings.ToUpper(r.Quote))
+}
+
+// conversionGraph monta o grafo de conversão a partir dos feeds da rede. Cada
+// feed gera uma aresta em cada sentido.
+func (s *ChainlinkService) conversionGraph() map[string][]conversionEdge {
+	graph := make(map[string][]conversionEdge)
+	addFeed := func(key string, feed config.Feed) {
+		asset := config.FeedAsset(key)
+		graph[asset] = append(graph[asset], conversionEdge{From: asset, To: feed.Quote, Feed: key})
+		graph[feed.Quote] = append(graph[feed.Quote], conversionEdge{From: feed.Quote, To: asset, Feed: key, Inverse: true})
+	}
+
+	for key, feed := range s.feedMap() {
+		addFeed(key, feed)
+	}
+
+	// A ordem das arestas define o caminho escolhido entre os de mesmo
+	// tamanho, e por isso é fixada.
+	for _, edges := range graph {
+		slices.SortFunc(edges, func(a, b conversionEdge) int {
+			return strings.Compare(a.To+" "+a.Feed, b.To+" "+b.Feed)
+		})
+	}
+	return graph
+}
+
+// IsConvertible informa se o símbolo pode ser usado em uma conversão pelo
+// grafo de feeds.
+func (s *ChainlinkService) IsConvertible(symbol string) bool {
+	if _, ok := s.conversionGraph()[symbol]; ok {
+		return true
+	}
+	_, ok := s.registryFeed(symbol)
+	return ok
+}
+
+// conversionPath busca, em largura, o caminho com menos feeds entre base e
+// quote. Ativos fora do registro, mas resolvidos pelo Feed Registry, entram
+// no grafo pelo seu feed em USD.
+func (s *ChainlinkService) conversionPath(base, quote string) ([]conversionEdge, error) {
+	if base == quote {
+		return nil, classify(ErrInvalidArgument, fmt.Errorf("ativo base e moeda de cotação são iguais: %s", base))
+	}
+
+	graph := s.conversionGraph()
+	for _, asset := range []string{base, quote} {
+		if _, ok := graph[asset]; ok {
+			continue
+		}
+		if feed, ok := s.registryFeed(asset); ok {
+			graph[asset] = append(graph[asset], conversionEdge{From: asset, To: feed.Quote, Feed: asset})
+			graph[feed.Quote] = append(graph[feed.Quote], conversionEdge{From: feed.Quote, To: asset, Feed: asset, Inverse: true})
+		}
+	}
+
+	for _, asset := range []string{base, quote} {
+		if _, ok := graph[asset]; !ok {
+			return nil, unsupportedAsset(asset)
+		}
+	}
+
+	previous := map[string]conversionEdge{}
+	visited := map[string]bool{base: true}
+	queue := []string{base}
+	for len(queue) > 0 && !visited[quote] {
+		current := queue[0]
+		queue = queue[1:]
+		for _, edge := range graph[current] {
+			if visited[edge.To] {
+				continue
+			}
+			visited[edge.To] = true
+			previous[edge.To] = edge
+			queue = append(queue, edge.To)
+		}
+	}
+
+	if !visited[quote] {
+		return nil, fmt.Errorf("%w de %s para %s", ErrNoConversionPath, strings.ToUpper(base), strings.ToUpper(quote))
+	}
+
+	var path []conversionEdge
+	for node := quote; node != base; node = previous[node].From {
+		path = append(path, previous[node])
+	}
+	slices.Reverse(path)
+	return path, nil
+}
+
+// GetCrossRate converte base em quote pelo caminho mais curto no grafo de
+// feeds. Todos os trechos são lidos no mesmo bloco e a taxa é calculada com
+// aritmética exata.
+func (s *ChainlinkService) GetCrossRate(ctx context.Context, base, quote string, opts QueryOptions) (*CrossRate, error) {
+	path, err := s.conversionPath(base, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	feeds := make([]string, len(path))
+	for i, edge := range path {
+		feeds[i] = edge.Feed
+	}
+
+	batch, err := s.fetchPrices(ctx, feeds, opts)
+	if err != nil {
+		return nil, err
+	}
+	// A conversão depende de todos os trechos.
+	if err := batch.Err(); err != nil {
+		return nil, err
+	}
+
+	crossRate := &CrossRate{
+		Base:  base,
+		Quote: quote,
+		Rate:  big.NewRat(1, 1),
+		Legs:  make([]*CrossRateLeg, len(path)),
+		Block: batch.Block,
+	}
+	for i, edge := range path {
+		priceData := batch.Prices[i]
+		// Mesmo no modo lenient, uma resposta não positiva não pode entrar no
+		// produto (nem ser invertida).
+		if priceData.Round.Answer.Sign() <= 0 {
+			return nil, &FeedValidationError{Asset: edge.Feed, Reason: ErrInvalidAnswer, Detail: fmt.Sprintf("answer %s menor ou igual a zero", priceData.Round.Answer)}
+		}
+
+		leg := &CrossRateLeg{From: edge.From, To: edge.To, Inverse: edge.Inverse, Price: priceData}
+		crossRate.Legs[i] = leg
+		crossRate.Rate.Mul(crossRate.Rate, leg.Rate())
+
+		if crossRate.Timestamp == 0 || priceData.Timestamp < crossRate.Timestamp {
+			crossRate.Timestamp = priceData.Timestamp
+		}
+		crossRate.Stale = crossRate.Stale || priceData.Stale
+	}
+
+	return crossRate, nil
+}
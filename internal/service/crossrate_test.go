@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+func newTestChainlinkService(feeds map[string]config.Feed) *ChainlinkService {
+	s := &ChainlinkService{}
+	s.feeds.Store(&feeds)
+	return s
+}
+
+// formatPath descreve o caminho como "de>para(feed)", com "~" nos trechos
+// invertidos.
+func formatPath(path []conversionEdge) string {
+	parts := make([]string, len(path))
+	for i, edge := range path {
+		inverse := ""
+		if edge.Inverse {
+			inverse = "~"
+		}
+		parts[i] = fmt.Sprintf("%s>%s(%s%s)", edge.From, edge.To, inverse, edge.Feed)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestConversionPath(t *testing.T) {
+	s := newTestChainlinkService(map[string]config.Feed{
+		"btc":      {Quote: "usd"},
+		"eth":      {Quote: "usd"},
+		"link":     {Quote: "usd"},
+		"paxg":     {Quote: "usd"},
+		"link/eth": {Quote: "eth"},
+		"btc/eth":  {Quote: "eth"},
+		"foo/bar":  {Quote: "bar"},
+	})
+
+	tests := []struct {
+		name    string
+		base    string
+		quote   string
+		want    string
+		wantErr error
+	}{
+		{"feed direto", "eth", "usd", "eth>usd(eth)", nil},
+		{"feed invertido", "usd", "eth", "usd>eth(~eth)", nil},
+		{"feed em ETH preferido ao caminho por USD", "link", "eth", "link>eth(link/eth)", nil},
+		{"feed em ETH invertido", "eth", "link", "eth>link(~link/eth)", nil},
+		{"dois trechos por USD", "paxg", "link", "paxg>usd(paxg) usd>link(~link)", nil},
+		{"empate resolvido pela ordem das arestas", "btc", "link", "btc>eth(btc/eth) eth>link(~link/eth)", nil},
+		{"mesmo ativo", "eth", "eth", "", ErrInvalidArgument},
+		{"ativo não suportado", "xyz", "usd", "", ErrUnsupportedAsset},
+		{"grafo desconexo", "foo", "usd", "", ErrNoConversionPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := s.conversionPath(tt.base, tt.quote)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if got := formatPath(path); got != tt.want {
+				t.Errorf("caminho = %s, esperado %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossRateLegRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   int64
+		decimals uint8
+		inverse  bool
+		want     string
+	}{
+		{"direto", 250000000000, 8, false, "2500/1"},
+		{"invertido", 250000000000, 8, true, "1/2500"},
+		{"invertido sem representação decimal finita", 3, 0, true, "1/3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leg := &CrossRateLeg{Inverse: tt.inverse, Price: &PriceData{Round: &RoundData{Answer: big.NewInt(tt.answer), Decimals: tt.decimals}}}
+			if got := leg.Rate().String(); got != tt.want {
+				t.Errorf("Rate() = %s, esperado %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// Categorias de falha do serviço, verificadas com errors.Is. As mensagens
+// originais são preservadas: as categorias só classificam o erro.
+var (
+	ErrUnsupportedAsset = errors.New("ativo não suportado")
+	ErrInvalidArgument  = errors.New("parâmetro inválido")
+	ErrNotFound         = errors.New("não encontrado")
+	// ErrUpstream indica uma falha na chamada ao nó RPC da rede.
+	ErrUpstream = errors.New("falha no nó RPC")
+	// ErrFXUnavailable indica que nenhuma fonte de câmbio forneceu a taxa.
+	ErrFXUnavailable = errors.New("taxa de câmbio indisponível")
+	// ErrTimeout indica que a chamada a uma origem excedeu o prazo.
+	ErrTimeout = errors.New("tempo esgotado")
+)
+
+// classifiedError associa um erro a uma categoria sem alterar sua mensagem.
+// Falhas por prazo esgotado também são marcadas com ErrTimeout.
+type classifiedError struct {
+	kind error
+	err  error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	if e.kind != ErrTimeout && isTimeout(e.err) {
+		return []error{e.kind, ErrTimeout, e.err}
+	}
+	return []error{e.kind, e.err}
+}
+
+func classify(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{kind: kind, err: err}
+}
+
+// upstreamError marca a falha de uma chamada ao nó RPC.
+func upstreamError(err error) error {
+	return classify(ErrUpstream, err)
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
@@ -1,57 +1,185 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
+	"log"
 	"math/big"
-	"net/http"
+	"strings"
 	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
 )
 
-const frankfurterAPIURL = "https://api.frankfurter.app/latest?from=USD&to=BRL"
+var ErrUnsupportedCurrency = errors.New("moeda não suportada")
 
-type ExchangeRateResponse struct {
-	Rates map[string]float64 `json:"rates"`
+// FXRate é a taxa de câmbio de From para To: uma unidade de From vale Rate
+// unidades de To. Provider identifica a fonte que forneceu a taxa.
+type FXRate struct {
+	From     string
+	To       string
+	Rate     *big.Rat
+	Provider string
+	// Date é a data de referência da taxa e NextUpdate, quando conhecida, a
+	// previsão da próxima publicação do provedor.
+	Date       time.Time
+	NextUpdate time.Time
 }
 
-type ExchangeService struct {
-	httpClient *http.Client
+// fxQuote é o valor de uma moeda na moeda de referência de um provedor.
+type fxQuote struct {
+	value      *big.Rat
+	date       time.Time
+	nextUpdate time.Time
 }
 
-func NewExchangeService() *ExchangeService {
-	return &ExchangeService{
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+// nextWeekdayAt retorna o próximo horário hour:minute, no fuso de loc, em um
+// dia útil (segunda a sexta) posterior a now.
+func nextWeekdayAt(now time.Time, hour, minute int, loc *time.Location) time.Time {
+	now = now.In(loc)
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
+	for !next.After(now) || next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+		next = next.AddDate(0, 0, 1)
 	}
+	return next
 }
 
-func (s *ExchangeService) GetBRLRate() (*big.Float, error) {
-	resp, err := s.httpClient.Get(frankfurterAPIURL)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao buscar taxa BRL: %w", err)
+// earliest retorna o menor instante não nulo.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
 	}
-	defer resp.Body.Close()
+	return a
+}
+
+// RateSource fornece taxas de câmbio entre moedas fiduciárias, identificadas
+// pelo código ISO 4217.
+type RateSource interface {
+	GetRate(ctx context.Context, from, to string) (*FXRate, error)
+	// GetRateAt retorna a taxa que estava em vigor no instante informado.
+	GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error)
+	// Currencies retorna as moedas suportadas, indexadas pelo código em
+	// maiúsculas, com o nome de cada uma.
+	Currencies(ctx context.Context) (map[string]string, error)
+}
+
+// RateProvider é uma fonte de câmbio que pode compor a cadeia do
+// ExchangeService. Um par que o provedor não cobre deve retornar um erro
+// com ErrUnsupportedCurrency.
+type RateProvider interface {
+	RateSource
+	Name() string
+}
+
+// ExchangeService consulta os provedores na ordem de prioridade, passando
+// ao seguinte quando um deles não cobre o par ou falha.
+type ExchangeService struct {
+	providers []RateProvider
+}
+
+func NewExchangeService(providers ...RateProvider) *ExchangeService {
+	return &ExchangeService{providers: providers}
+}
+
+func (s *ExchangeService) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	return s.firstRate(from, to, func(provider RateProvider, from, to string) (*FXRate, error) {
+		return provider.GetRate(ctx, from, to)
+	})
+}
+
+func (s *ExchangeService) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	return s.firstRate(from, to, func(provider RateProvider, from, to string) (*FXRate, error) {
+		return provider.GetRateAt(ctx, from, to, at)
+	})
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("falha ao buscar taxa BRL, código de status: %d", resp.StatusCode)
+// firstRate retorna a taxa do primeiro provedor que a fornecer.
+func (s *ExchangeService) firstRate(from, to string, fetch func(provider RateProvider, from, to string) (*FXRate, error)) (*FXRate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return &FXRate{From: from, To: to, Rate: big.NewRat(1, 1)}, nil
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao ler o corpo da resposta: %w", err)
+	var errs []error
+	for _, provider := range s.providers {
+		rate, err := fetch(provider, from, to)
+		if err == nil {
+			return rate, nil
+		}
+		if !errors.Is(err, ErrUnsupportedCurrency) {
+			log.Printf("provedor de câmbio %s falhou para %s/%s: %v", provider.Name(), from, to, err)
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, fmt.Errorf("%w: nenhum provedor oferece o par %s/%s", ErrUnsupportedCurrency, from, to)
+	}
+	return nil, classify(ErrFXUnavailable, fmt.Errorf("falha ao buscar taxa %s/%s: %w", from, to, errors.Join(errs...)))
+}
+
+// Currencies reúne as moedas de todos os provedores. O nome informado é o
+// do provedor de maior prioridade.
+func (s *ExchangeService) Currencies(ctx context.Context) (map[string]string, error) {
+	currencies := make(map[string]string)
+	var errs []error
+	for _, provider := range s.providers {
+		provided, err := provider.Currencies(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+			continue
+		}
+		for code, name := range provided {
+			if _, found := currencies[code]; !found {
+				currencies[code] = name
+			}
+		}
 	}
 
-	var result ExchangeRateResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("falha ao decodificar resposta: %w", err)
+	if len(currencies) == 0 && len(errs) > 0 {
+		return nil, classify(ErrFXUnavailable, fmt.Errorf("falha ao buscar moedas suportadas: %w", errors.Join(errs...)))
 	}
+	return currencies, nil
+}
 
-	brlRate, ok := result.Rates["BRL"]
-	if !ok {
-		return nil, fmt.Errorf("taxa BRL não encontrada na resposta")
+// checkCurrency confere se a moeda é suportada pela fonte de câmbio. USD,
+// em que os feeds são cotados, dispensa a consulta.
+func checkCurrency(ctx context.Context, rates RateSource, currency string) error {
+	if strings.EqualFold(currency, config.DefaultQuote) {
+		return nil
+	}
+	currencies, err := rates.Currencies(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := currencies[strings.ToUpper(currency)]; !ok {
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedCurrency, currency)
 	}
+	return nil
+}
 
-	return new(big.Float).SetFloat64(brlRate), nil
+// crossFXRate calcula a taxa de from para to a partir do valor de cada moeda
+// em uma moeda de referência, como fazem os provedores que cotam tudo em
+// relação a USD ou BRL. A data é a da cotação mais antiga.
+func crossFXRate(from, to, provider string, quoteOf func(currency string) (*fxQuote, error)) (*FXRate, error) {
+	fromQuote, err := quoteOf(from)
+	if err != nil {
+		return nil, err
+	}
+	toQuote, err := quoteOf(to)
+	if err != nil {
+		return nil, err
+	}
+	if toQuote.value.Sign() <= 0 {
+		return nil, fmt.Errorf("cotação inválida para %s", to)
+	}
+	return &FXRate{
+		From:       from,
+		To:         to,
+		Rate:       new(big.Rat).Quo(fromQuote.value, toQuote.value),
+		Provider:   provider,
+		Date:       earliest(fromQuote.date, toQuote.date),
+		NextUpdate: earliest(fromQuote.nextUpdate, toQuote.nextUpdate),
+	}, nil
 }
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// EnableFeedRegistry faz com que ativos ausentes do registro de feeds sejam
+// lidos pelo Feed Registry da Chainlink, resolvendo o símbolo para o
+// endereço de Denominations correspondente.
+func (s *ChainlinkService) EnableFeedRegistry() error {
+	registry, err := contracts.NewFeedRegistry(common.HexToAddress(contracts.FeedRegistryAddress), s.client)
+	if err != nil {
+		return fmt.Errorf("falha ao instanciar o Feed Registry: %w", err)
+	}
+	s.feedRegistry = registry
+	return nil
+}
+
+// registryFeed retorna a configuração usada para um ativo resolvido pelo
+// Feed Registry. Sem heartbeat conhecido, a idade do preço não é validada.
+// Moedas fiduciárias não são ativos: elas são convertidas pelo câmbio, com ou
+// sem o Feed Registry.
+func (s *ChainlinkService) registryFeed(asset string) (config.Feed, bool) {
+	if s.feedRegistry == nil {
+		return config.Feed{}, false
+	}
+	if address, ok := contracts.ResolveDenomination(asset); !ok || contracts.IsFiatDenomination(address) {
+		return config.Feed{}, false
+	}
+	return config.Feed{Quote: config.DefaultQuote}, true
+}
+
+func (s *ChainlinkService) loadRegistryHandle(ctx context.Context, asset string, feed config.Feed) (*feedHandle, error) {
+	base, _ := contracts.ResolveDenomination(asset)
+	quote, ok := contracts.ResolveDenomination(feed.Quote)
+	if !ok {
+		return nil, classify(ErrUnsupportedAsset, fmt.Errorf("moeda de cotação '%s' não suportada pelo Feed Registry", feed.Quote))
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	aggregator, err := s.feedRegistry.GetFeed(callOpts, base, quote)
+	if err != nil {
+		if isRevert(err) {
+			return nil, classify(ErrUnsupportedAsset, fmt.Errorf("ativo '%s' não suportado: feed não encontrado no Feed Registry: %w", asset, err))
+		}
+		return nil, upstreamError(fmt.Errorf("falha ao buscar feed de %s no Feed Registry: %w", asset, err))
+	}
+
+	decimals, err := s.feedRegistry.Decimals(callOpts, base, quote)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar decimais para %s: %w", asset, err))
+	}
+
+	description, err := s.feedRegistry.Description(callOpts, base, quote)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar descrição para %s: %w", asset, err))
+	}
+
+	version, err := s.feedRegistry.Version(callOpts, base, quote)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar versão para %s: %w", asset, err))
+	}
+
+	phaseID, err := s.feedRegistry.GetCurrentPhaseId(callOpts, base, quote)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar fase para %s: %w", asset, err))
+	}
+
+	log.Printf("feed %s carregado pelo Feed Registry: %s (versão %s, %d decimais, fase %d)", asset, description, version, decimals, phaseID)
+
+	return &feedHandle{
+		address:     aggregator,
+		decimals:    decimals,
+		description: description,
+		version:     version,
+		phaseID:     phaseID,
+		registry:    &registryPair{base: base, quote: quote},
+	}, nil
+}
+
+// executionRevertedCode é o código JSON-RPC com que o nó informa uma chamada
+// revertida pelo contrato.
+const executionRevertedCode = 3
+
+// isRevert indica que a chamada chegou ao contrato e foi revertida, como o
+// "Feed not found" do Feed Registry. Falhas de conexão, limites de requisição
+// e erros do nó não são reverts.
+func isRevert(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == executionRevertedCode {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, "execution reverted") || strings.Contains(message, "Feed not found")
+}
+
+type roundResult = struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}
+
+// latestRoundData lê o último round pelo proxy do feed ou, para feeds
+// resolvidos pelo Feed Registry, pelo próprio registry. Em ambos os casos o
+// roundId já vem com a fase codificada.
+func (s *ChainlinkService) latestRoundData(callOpts *bind.CallOpts, handle *feedHandle) (roundResult, error) {
+	if handle.registry != nil {
+		return s.feedRegistry.LatestRoundData(callOpts, handle.registry.base, handle.registry.quote)
+	}
+	return handle.contract.LatestRoundData(callOpts)
+}
+
+// requireProxy rejeita operações que dependem do proxy do feed, como a
+// navegação pelos rounds, e que não são suportadas pelo Feed Registry. É uma
+// limitação conhecida, informada ao cliente como parâmetro inválido (400).
+func requireProxy(asset string, handle *feedHandle) error {
+	if handle.registry != nil {
+		return classify(ErrInvalidArgument, fmt.Errorf("feed de %s resolvido pelo Feed Registry: histórico não disponível, adicione o feed ao registro de feeds", asset))
+	}
+	return nil
+}
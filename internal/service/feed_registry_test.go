@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+func TestIsRevert(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"revert com código 3", &testRPCError{3, "execution reverted: Feed not found"}, true},
+		{"revert sem dados", &testRPCError{-32000, "execution reverted"}, true},
+		{"revert encapsulado", fmt.Errorf("chamada: %w", errors.New("execution reverted: Feed not found")), true},
+		{"limite de requisições", &testRPCError{429, "too many requests"}, false},
+		{"erro do nó", &testRPCError{-32603, "internal error"}, false},
+		{"conexão recusada", errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), false},
+		{"prazo esgotado", context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRevert(tt.err); got != tt.want {
+				t.Errorf("isRevert(%v) = %v, esperado %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
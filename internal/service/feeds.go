@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/singleflight"
+)
+
+// registryPair identifica um feed no Feed Registry.
+type registryPair struct {
+	base  common.Address
+	quote common.Address
+}
+
+// feedHandle guarda o contrato já instanciado e os metadados imutáveis de um
+// feed, para que uma leitura de preço custe um único eth_call. O phaseID
+// permite detectar a troca do agregador por trás do proxy. Feeds lidos pelo
+// Feed Registry têm registry preenchido e contract nil, e address é o
+// agregador atual.
+type feedHandle struct {
+	address     common.Address
+	contract    *contracts.AggregatorV3Interface
+	decimals    uint8
+	description string
+	version     *big.Int
+	phaseID     uint16
+	// decimalsOverride é o valor de decimals do registro, zero quando os
+	// decimais foram lidos do contrato.
+	decimalsOverride uint8
+
+	registry *registryPair
+}
+
+type feedCache struct {
+	mu      sync.RWMutex
+	handles map[string]*feedHandle
+	loads   singleflight.Group
+}
+
+func newFeedCache() *feedCache {
+	return &feedCache{handles: make(map[string]*feedHandle)}
+}
+
+func (s *ChainlinkService) feedMap() map[string]config.Feed {
+	return *s.feeds.Load()
+}
+
+func (s *ChainlinkService) feed(asset string) (config.Feed, bool) {
+	if feed, ok := s.feedMap()[asset]; ok {
+		return feed, true
+	}
+	return s.registryFeed(asset)
+}
+
+// Precision retorna as casas decimais do preço exibido do ativo.
+func (s *ChainlinkService) Precision(asset string) int {
+	if feed, _ := s.feed(asset); feed.Precision != nil {
+		return *feed.Precision
+	}
+	return config.DefaultPrecision
+}
+
+// priceFeeds retorna os feeds cotados em USD.
+func (s *ChainlinkService) priceFeeds() map[string]config.Feed {
+	feeds := make(map[string]config.Feed)
+	for asset, feed := range s.feedMap() {
+		if feed.Quote == config.DefaultQuote {
+			feeds[asset] = feed
+		}
+	}
+	return feeds
+}
+
+func (s *ChainlinkService) checkUSDQuote(asset string) error {
+	feed, ok := s.feed(asset)
+	if !ok {
+		return unsupportedAsset(asset)
+	}
+	if feed.Quote != config.DefaultQuote {
+		return classify(ErrUnsupportedAsset, fmt.Errorf("feed de %s é cotado em %s, e não em USD", asset, strings.ToUpper(feed.Quote)))
+	}
+	return nil
+}
+
+// SetFeeds substitui o conjunto de feeds. Os handles de feeds removidos ou
+// cujo endereço ou decimais mudaram são descartados e recarregados sob
+// demanda.
+func (s *ChainlinkService) SetFeeds(feeds map[string]config.Feed) {
+	s.feeds.Store(&feeds)
+
+	s.feedCache.mu.Lock()
+	defer s.feedCache.mu.Unlock()
+	for asset, handle := range s.feedCache.handles {
+		feed, ok := feeds[asset]
+		if !ok || common.HexToAddress(feed.Address) != handle.address || feed.Decimals != handle.decimalsOverride {
+			delete(s.feedCache.handles, asset)
+		}
+	}
+}
+
+func (s *ChainlinkService) feedHandle(ctx context.Context, asset string) (*feedHandle, error) {
+	s.feedCache.mu.RLock()
+	handle, ok := s.feedCache.handles[asset]
+	s.feedCache.mu.RUnlock()
+	if ok {
+		return handle, nil
+	}
+
+	loaded, err, _ := s.feedCache.loads.Do(asset, func() (interface{}, error) {
+		handle, err := s.loadFeedHandle(ctx, asset)
+		if err != nil {
+			return nil, err
+		}
+		s.feedCache.mu.Lock()
+		s.feedCache.handles[asset] = handle
+		s.feedCache.mu.Unlock()
+		return handle, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loaded.(*feedHandle), nil
+}
+
+func (s *ChainlinkService) loadFeedHandle(ctx context.Context, asset string) (*feedHandle, error) {
+	feed, ok := s.feed(asset)
+	if !ok {
+		return nil, unsupportedAsset(asset)
+	}
+	if feed.Address == "" {
+		return s.loadRegistryHandle(ctx, asset, feed)
+	}
+
+	address := common.HexToAddress(feed.Address)
+	contract, err := contracts.NewAggregatorV3Interface(address, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao instanciar contrato para %s: %w", asset, err)
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	decimals := feed.Decimals
+	if decimals == 0 {
+		decimals, err = contract.Decimals(callOpts)
+		if err != nil {
+			return nil, upstreamError(fmt.Errorf("falha ao buscar decimais para %s: %w", asset, err))
+		}
+	}
+
+	description, err := contract.Description(callOpts)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar descrição para %s: %w", asset, err))
+	}
+
+	version, err := contract.Version(callOpts)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar versão para %s: %w", asset, err))
+	}
+
+	phaseID, err := contract.PhaseId(callOpts)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar fase para %s: %w", asset, err))
+	}
+
+	log.Printf("feed %s carregado: %s (versão %s, %d decimais, fase %d)", asset, description, version, decimals, phaseID)
+
+	return &feedHandle{
+		address:     address,
+		contract:    contract,
+		decimals:    decimals,
+		description: description,
+		version:     version,
+		phaseID:     phaseID,
+
+		decimalsOverride: feed.Decimals,
+	}, nil
+}
+
+// checkPhase descarta o handle em cache quando um round lido pertence a uma
+// fase mais nova que a conhecida, ou seja, o proxy passou a apontar para outro
+// agregador e os metadados (como decimals) precisam ser relidos.
+func (s *ChainlinkService) checkPhase(ctx context.Context, asset string, handle *feedHandle, roundID *big.Int) (*feedHandle, error) {
+	phaseID, _ := contracts.DecodeRoundID(roundID)
+	if phaseID <= handle.phaseID {
+		return handle, nil
+	}
+
+	log.Printf("feed %s mudou da fase %d para a fase %d, recarregando metadados", asset, handle.phaseID, phaseID)
+
+	s.feedCache.mu.Lock()
+	if s.feedCache.handles[asset] == handle {
+		delete(s.feedCache.handles, asset)
+	}
+	s.feedCache.mu.Unlock()
+
+	return s.feedHandle(ctx, asset)
+}
+
+// LoadFeeds carrega antecipadamente os handles de todos os feeds
+// configurados. Falhas não são fatais: o feed é carregado sob demanda.
+func (s *ChainlinkService) LoadFeeds(ctx context.Context) {
+	var wg sync.WaitGroup
+	for asset := range s.feedMap() {
+		wg.Add(1)
+		go func(asset string) {
+			defer wg.Done()
+			if _, err := s.feedHandle(ctx, asset); err != nil {
+				log.Printf("não foi possível carregar o feed %s: %v", asset, err)
+			}
+		}(asset)
+	}
+	wg.Wait()
+}
+
+func unsupportedAsset(asset string) error {
+	return classify(ErrUnsupportedAsset, fmt.Errorf("ativo '%s' não suportado", asset))
+}
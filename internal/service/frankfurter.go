@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const frankfurterAPIURL = "https://api.frankfurter.app"
+
+// frankfurterPublishHour é o horário (UTC) após o qual as taxas do dia,
+// publicadas pelo BCE por volta das 16h CET, já estão disponíveis.
+const frankfurterPublishHour = 16
+
+type ExchangeRateResponse struct {
+	Date  string                 `json:"date"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// FrankfurterProvider obtém as taxas de referência do Banco Central Europeu
+// pela API Frankfurter.
+type FrankfurterProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewFrankfurterProvider(baseURL string) *FrankfurterProvider {
+	if baseURL == "" {
+		baseURL = frankfurterAPIURL
+	}
+	return &FrankfurterProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (p *FrankfurterProvider) Name() string {
+	return "frankfurter"
+}
+
+func (p *FrankfurterProvider) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	rate, err := p.rate(ctx, "latest", from, to)
+	if err != nil {
+		return nil, err
+	}
+	rate.NextUpdate = nextWeekdayAt(time.Now(), frankfurterPublishHour, 0, time.UTC)
+	return rate, nil
+}
+
+// GetRateAt usa a taxa publicada na data de at (UTC). Em fins de semana e
+// feriados a API responde com a do último dia útil anterior.
+func (p *FrankfurterProvider) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	return p.rate(ctx, at.UTC().Format(time.DateOnly), from, to)
+}
+
+func (p *FrankfurterProvider) rate(ctx context.Context, date, from, to string) (*FXRate, error) {
+	query := url.Values{"from": {from}, "to": {to}}
+	var result ExchangeRateResponse
+	if err := p.get(ctx, "/"+date+"?"+query.Encode(), &result); err != nil {
+		// A API responde 404 quando uma das moedas não é conhecida.
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s/%s", ErrUnsupportedCurrency, from, to)
+		}
+		return nil, fmt.Errorf("falha ao buscar taxa %s/%s: %w", from, to, err)
+	}
+
+	number, ok := result.Rates[to]
+	if !ok {
+		return nil, fmt.Errorf("taxa %s/%s não encontrada na resposta", from, to)
+	}
+	rate, ok := new(big.Rat).SetString(number.String())
+	if !ok {
+		return nil, fmt.Errorf("taxa %s/%s inválida na resposta: %q", from, to, number)
+	}
+
+	rateDate, err := time.Parse(time.DateOnly, result.Date)
+	if err != nil {
+		return nil, fmt.Errorf("data inválida na resposta: %q", result.Date)
+	}
+
+	return &FXRate{
+		From:     from,
+		To:       to,
+		Rate:     rate,
+		Provider: p.Name(),
+		Date:     rateDate,
+	}, nil
+}
+
+func (p *FrankfurterProvider) Currencies(ctx context.Context) (map[string]string, error) {
+	var currencies map[string]string
+	if err := p.get(ctx, "/currencies", &currencies); err != nil {
+		return nil, fmt.Errorf("falha ao buscar moedas suportadas: %w", err)
+	}
+	return currencies, nil
+}
+
+func (p *FrankfurterProvider) get(ctx context.Context, path string, target any) error {
+	return getJSON(ctx, p.httpClient, p.baseURL+path, target)
+}
+
+type httpStatusError struct {
+	StatusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("código de status: %d", e.StatusCode)
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &httpStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("falha ao ler o corpo da resposta: %w", err)
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("falha ao decodificar resposta: %w", err)
+	}
+	return nil
+}
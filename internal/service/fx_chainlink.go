@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ChainlinkRateProvider calcula o câmbio a partir dos feeds on-chain que
+// cotam moedas fiduciárias em USD.
+type ChainlinkRateProvider struct {
+	client *ethclient.Client
+	feeds  map[string]config.FiatFeed
+
+	mu       sync.Mutex
+	decimals map[string]uint8
+}
+
+func NewChainlinkRateProvider(client *ethclient.Client, feeds map[string]config.FiatFeed) *ChainlinkRateProvider {
+	return &ChainlinkRateProvider{
+		client:   client,
+		feeds:    feeds,
+		decimals: make(map[string]uint8),
+	}
+}
+
+func (p *ChainlinkRateProvider) Name() string {
+	return "chainlink"
+}
+
+func (p *ChainlinkRateProvider) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	return crossFXRate(from, to, p.Name(), func(currency string) (*fxQuote, error) {
+		return p.usdValue(ctx, currency, time.Time{})
+	})
+}
+
+// GetRateAt usa o round de cada feed que estava em vigor em at.
+func (p *ChainlinkRateProvider) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	return crossFXRate(from, to, p.Name(), func(currency string) (*fxQuote, error) {
+		return p.usdValue(ctx, currency, at)
+	})
+}
+
+func (p *ChainlinkRateProvider) Currencies(ctx context.Context) (map[string]string, error) {
+	currencies := map[string]string{"USD": "United States Dollar"}
+	for code, feed := range p.feeds {
+		currencies[code] = feed.Name
+	}
+	return currencies, nil
+}
+
+// usdValue retorna quantos USD valia uma unidade da moeda em at, ou agora
+// quando at é zero. Um feed sem atualização dentro do heartbeat é recusado,
+// para que a cadeia passe ao próximo provedor.
+func (p *ChainlinkRateProvider) usdValue(ctx context.Context, currency string, at time.Time) (*fxQuote, error) {
+	if currency == strings.ToUpper(config.DefaultQuote) {
+		return &fxQuote{value: big.NewRat(1, 1)}, nil
+	}
+	feed, ok := p.feeds[currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	aggregator, err := contracts.NewAggregatorV3Interface(common.HexToAddress(feed.Address), p.client)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao instanciar o feed %s/USD: %w", currency, err)
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	decimals, err := p.feedDecimals(callOpts, currency, aggregator)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := aggregator.LatestRoundData(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar dados do feed %s/USD: %w", currency, err)
+	}
+
+	reference := time.Now()
+	if !at.IsZero() {
+		reference = at
+		if round.UpdatedAt.Int64() > at.Unix() {
+			phases := newPhaseCache(aggregator, p.client, callOpts)
+			roundID, err := searchRoundAt(aggregator, phases, callOpts, round.RoundId, at.Unix())
+			if err != nil {
+				return nil, fmt.Errorf("falha ao buscar round do feed %s/USD em %s: %w", currency, at.UTC().Format(time.RFC3339), err)
+			}
+			if round, err = aggregator.GetRoundData(callOpts, roundID); err != nil {
+				return nil, fmt.Errorf("falha ao buscar round %s do feed %s/USD: %w", roundID, currency, err)
+			}
+		}
+	}
+
+	if round.Answer.Sign() <= 0 {
+		return nil, fmt.Errorf("feed %s/USD: %w", currency, ErrInvalidAnswer)
+	}
+	updatedAt := time.Unix(round.UpdatedAt.Int64(), 0)
+	if age := reference.Sub(updatedAt); age > feed.Heartbeat {
+		return nil, fmt.Errorf("feed %s/USD: %w: última atualização há %s", currency, ErrStalePrice, age.Round(time.Second))
+	}
+
+	// O feed também é atualizado por desvio, então a próxima atualização não
+	// é conhecida e o cache usa o TTL configurado.
+	return &fxQuote{value: scalePrice(round.Answer, decimals), date: updatedAt}, nil
+}
+
+func (p *ChainlinkRateProvider) feedDecimals(callOpts *bind.CallOpts, currency string, aggregator *contracts.AggregatorV3Interface) (uint8, error) {
+	p.mu.Lock()
+	decimals, ok := p.decimals[currency]
+	p.mu.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	decimals, err := aggregator.Decimals(callOpts)
+	if err != nil {
+		return 0, fmt.Errorf("falha ao buscar decimais do feed %s/USD: %w", currency, err)
+	}
+
+	p.mu.Lock()
+	p.decimals[currency] = decimals
+	p.mu.Unlock()
+	return decimals, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	DefaultHistoryLimit = 20
+	MaxHistoryLimit     = 100
+)
+
+type RoundData struct {
+	RoundID           *big.Int
+	PhaseID           uint16
+	AggregatorRoundID uint64
+	Aggregator        common.Address
+	Answer            *big.Int
+	Decimals          uint8
+	Price             *big.Rat
+	StartedAt         int64
+	UpdatedAt         int64
+	AnsweredInRound   *big.Int
+	// FX é a taxa usada para converter Price de USD, em rounds convertidos
+	// para outra moeda.
+	FX *FXRate
+}
+
+type PriceHistory struct {
+	Pair string
+	// Currency é a moeda dos preços, quando o histórico foi convertido.
+	Currency   string
+	Rounds     []*RoundData
+	NextCursor *big.Int
+	Block      *BlockRef
+}
+
+func newRoundData(roundID, answer, startedAt, updatedAt, answeredInRound *big.Int, decimals uint8) *RoundData {
+	phaseID, aggregatorRoundID := contracts.DecodeRoundID(roundID)
+	return &RoundData{
+		RoundID:           roundID,
+		PhaseID:           phaseID,
+		AggregatorRoundID: aggregatorRoundID,
+		Answer:            answer,
+		Decimals:          decimals,
+		Price:             scalePrice(answer, decimals),
+		StartedAt:         startedAt.Int64(),
+		UpdatedAt:         updatedAt.Int64(),
+		AnsweredInRound:   answeredInRound,
+	}
+}
+
+// NewRoundIterator cria um iterador que parte do round informado (ou do mais
+// recente, quando start é nil) em direção aos rounds mais antigos.
+func (s *ChainlinkService) NewRoundIterator(ctx context.Context, asset string, start *big.Int, opts QueryOptions) (*RoundIterator, error) {
+	handle, err := s.feedHandle(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProxy(asset, handle); err != nil {
+		return nil, err
+	}
+
+	priceFeed, decimals := handle.contract, handle.decimals
+	callOpts := opts.callOpts(ctx)
+
+	if start == nil {
+		latestRoundData, err := priceFeed.LatestRoundData(callOpts)
+		if err != nil {
+			return nil, upstreamError(fmt.Errorf("falha ao buscar dados para %s: %w", asset, err))
+		}
+		if handle, err = s.checkPhase(ctx, asset, handle, latestRoundData.RoundId); err != nil {
+			return nil, err
+		}
+		priceFeed, decimals = handle.contract, handle.decimals
+		start = latestRoundData.RoundId
+	}
+
+	return newRoundIterator(priceFeed, s.client, callOpts, decimals, start), nil
+}
+
+// GetPriceHistory percorre os rounds do feed do mais recente para o mais
+// antigo. O cursor é o roundId a partir do qual a busca começa (inclusive);
+// quando nil, parte do round mais recente.
+func (s *ChainlinkService) GetPriceHistory(ctx context.Context, asset string, limit int, cursor *big.Int, opts QueryOptions) (*PriceHistory, error) {
+	if limit <= 0 || limit > MaxHistoryLimit {
+		return nil, classify(ErrInvalidArgument, fmt.Errorf("limite deve estar entre 1 e %d", MaxHistoryLimit))
+	}
+
+	it, err := s.NewRoundIterator(ctx, asset, cursor, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &PriceHistory{
+		Pair:   s.pairName(asset),
+		Rounds: make([]*RoundData, 0, limit),
+		Block:  opts.Block,
+	}
+
+	for len(history.Rounds) < limit && it.Next() {
+		history.Rounds = append(history.Rounds, it.Round)
+	}
+
+	if err := it.Error(); err != nil && len(history.Rounds) == 0 {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar histórico para %s: %w", asset, err))
+	}
+
+	history.NextCursor = it.Cursor()
+	return history, nil
+}
+
+// GetPriceHistoryFiat é como GetPriceHistory, com o preço de cada round
+// convertido pela taxa de câmbio em vigor no seu updatedAt.
+func (s *ChainlinkService) GetPriceHistoryFiat(ctx context.Context, asset, currency string, limit int, cursor *big.Int, opts QueryOptions) (*PriceHistory, error) {
+	if err := s.checkUSDQuote(asset); err != nil {
+		return nil, err
+	}
+	if err := s.CheckCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	history, err := s.GetPriceHistory(ctx, asset, limit, cursor, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, len(history.Rounds))
+	for i, round := range history.Rounds {
+		timestamps[i] = round.UpdatedAt
+	}
+	rates, err := s.fiatRatesAt(ctx, currency, timestamps)
+	if err != nil {
+		return nil, err
+	}
+
+	history.Currency = strings.ToUpper(currency)
+	history.Pair = fmt.Sprintf("%s/%s", strings.ToUpper(asset), history.Currency)
+	for i, round := range history.Rounds {
+		converted := *round
+		converted.Price = new(big.Rat).Mul(round.Price, rates[i].Rate)
+		if rates[i].From != rates[i].To {
+			converted.FX = rates[i]
+		}
+		history.Rounds[i] = &converted
+	}
+	return history, nil
+}
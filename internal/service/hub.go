@@ -0,0 +1,83 @@
+package service
+
+import (
+	"sort"
+	"sync"
+)
+
+// priceHub avisa os assinantes sobre quais ativos tiveram o preço alterado.
+// O aviso nunca bloqueia quem publica: cada assinatura acumula os ativos
+// pendentes e um consumidor lento recebe apenas o estado mais recente.
+type priceHub struct {
+	mu   sync.Mutex
+	subs map[*PriceSubscription]struct{}
+}
+
+func newPriceHub() *priceHub {
+	return &priceHub{subs: make(map[*PriceSubscription]struct{})}
+}
+
+func (h *priceHub) subscribe() *PriceSubscription {
+	sub := &PriceSubscription{
+		hub:     h,
+		pending: make(map[string]struct{}),
+		notify:  make(chan struct{}, 1),
+	}
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+func (h *priceHub) publish(asset string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		sub.offer(asset)
+	}
+}
+
+type PriceSubscription struct {
+	hub *priceHub
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	notify  chan struct{}
+}
+
+func (s *PriceSubscription) offer(asset string) {
+	s.mu.Lock()
+	s.pending[asset] = struct{}{}
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// C sinaliza que há ativos pendentes, a serem lidos com Drain.
+func (s *PriceSubscription) C() <-chan struct{} {
+	return s.notify
+}
+
+// Drain retorna, em ordem alfabética, os ativos alterados desde a última
+// chamada.
+func (s *PriceSubscription) Drain() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assets := make([]string, 0, len(s.pending))
+	for asset := range s.pending {
+		assets = append(assets, asset)
+	}
+	clear(s.pending)
+	sort.Strings(assets)
+	return assets
+}
+
+func (s *PriceSubscription) Close() {
+	s.hub.mu.Lock()
+	delete(s.hub.subs, s)
+	s.hub.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+type feedJob struct {
+	feed   config.Feed
+	cancel context.CancelFunc
+}
+
+// feedJobs mantém uma goroutine por feed e as reconcilia quando o conjunto
+// de feeds muda: feeds novos ou alterados são (re)iniciados e os removidos
+// são encerrados.
+type feedJobs struct {
+	run func(ctx context.Context, asset string, feed config.Feed)
+
+	mu   sync.Mutex
+	ctx  context.Context
+	jobs map[string]feedJob
+	wg   sync.WaitGroup
+}
+
+func newFeedJobs(run func(ctx context.Context, asset string, feed config.Feed)) *feedJobs {
+	return &feedJobs{run: run, jobs: make(map[string]feedJob)}
+}
+
+// start inicia as goroutines dos feeds e bloqueia até que ctx seja
+// cancelado e todas elas terminem.
+func (j *feedJobs) start(ctx context.Context, feeds map[string]config.Feed) {
+	j.mu.Lock()
+	j.ctx = ctx
+	j.mu.Unlock()
+
+	j.sync(feeds)
+	<-ctx.Done()
+	j.wg.Wait()
+}
+
+// sync não tem efeito antes de start.
+func (j *feedJobs) sync(feeds map[string]config.Feed) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.ctx == nil || j.ctx.Err() != nil {
+		return
+	}
+
+	for asset, job := range j.jobs {
+		if feed, ok := feeds[asset]; !ok || !feed.Equal(job.feed) {
+			job.cancel()
+			delete(j.jobs, asset)
+		}
+	}
+
+	for asset, feed := range feeds {
+		if _, running := j.jobs[asset]; running {
+			continue
+		}
+		ctx, cancel := context.WithCancel(j.ctx)
+		j.jobs[asset] = feedJob{feed: feed, cancel: cancel}
+		j.wg.Add(1)
+		go func(asset string, feed config.Feed) {
+			defer j.wg.Done()
+			j.run(ctx, asset, feed)
+		}(asset, feed)
+	}
+}
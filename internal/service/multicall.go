@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errMulticallFailed = errors.New("falha na chamada ao Multicall3")
+
+// Endereço canônico do Multicall3, o mesmo em todas as redes onde ele foi
+// implantado.
+const multicall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+type multicallReader struct {
+	backend    bind.ContractBackend
+	multicall  *contracts.Multicall3Raw
+	aggregator *abi.ABI
+	registry   *abi.ABI
+
+	mu          sync.Mutex
+	probe       multicallProbe
+	probing     bool
+	nextAttempt time.Time
+}
+
+// multicallProbe é o resultado da verificação do Multicall3. Apenas uma
+// resposta do nó é definitiva; após uma falha a verificação é refeita.
+type multicallProbe int
+
+const (
+	multicallUnknown multicallProbe = iota
+	multicallAvailable
+	multicallUnavailable
+)
+
+const (
+	multicallProbeTimeout = 5 * time.Second
+	// multicallProbeRetry evita uma nova verificação a cada requisição
+	// enquanto o nó está instável; até lá são usadas chamadas paralelas.
+	multicallProbeRetry = 30 * time.Second
+)
+
+func newMulticallReader(backend bind.ContractBackend) (*multicallReader, error) {
+	multicall, err := contracts.NewMulticall3(common.HexToAddress(multicall3Address), backend)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao instanciar Multicall3: %w", err)
+	}
+
+	aggregatorABI, err := contracts.AggregatorV3InterfaceMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao carregar ABI do agregador: %w", err)
+	}
+
+	registryABI, err := contracts.FeedRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("falha ao carregar ABI do Feed Registry: %w", err)
+	}
+
+	return &multicallReader{
+		backend:    backend,
+		multicall:  &contracts.Multicall3Raw{Contract: multicall},
+		aggregator: aggregatorABI,
+		registry:   registryABI,
+	}, nil
+}
+
+// isAvailable verifica se existe código no endereço do Multicall3 na rede
+// conectada. O resultado só é guardado quando o nó responde: uma falha ou o
+// cancelamento da requisição que disparou a verificação não desativa o
+// Multicall3, e a verificação é refeita depois de multicallProbeRetry.
+// Enquanto uma verificação está em andamento, as demais requisições usam
+// chamadas paralelas em vez de esperar por ela.
+func (r *multicallReader) isAvailable(ctx context.Context) bool {
+	r.mu.Lock()
+	if r.probe != multicallUnknown {
+		available := r.probe == multicallAvailable
+		r.mu.Unlock()
+		return available
+	}
+	if r.probing || time.Now().Before(r.nextAttempt) {
+		r.mu.Unlock()
+		return false
+	}
+	r.probing = true
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), multicallProbeTimeout)
+	code, err := r.backend.CodeAt(ctx, common.HexToAddress(multicall3Address), nil)
+	cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probing = false
+	if err != nil {
+		r.nextAttempt = time.Now().Add(multicallProbeRetry)
+		log.Printf("não foi possível verificar o Multicall3, usando chamadas paralelas: %v", err)
+		return false
+	}
+
+	if len(code) > 0 {
+		r.probe = multicallAvailable
+	} else {
+		r.probe = multicallUnavailable
+		log.Println("Multicall3 não disponível nesta rede, usando chamadas paralelas")
+	}
+	return r.probe == multicallAvailable
+}
+
+type latestRoundResult struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+	Err             error
+}
+
+// latestRoundCall monta a chamada a latestRoundData do proxy do feed ou,
+// para feeds resolvidos pelo Feed Registry, do registry. As duas retornam os
+// mesmos campos.
+func (r *multicallReader) latestRoundCall(handle *feedHandle) (contracts.Multicall3Call3, error) {
+	if handle.registry != nil {
+		callData, err := r.registry.Pack("latestRoundData", handle.registry.base, handle.registry.quote)
+		if err != nil {
+			return contracts.Multicall3Call3{}, fmt.Errorf("falha ao codificar latestRoundData: %w", err)
+		}
+		return contracts.Multicall3Call3{Target: common.HexToAddress(contracts.FeedRegistryAddress), AllowFailure: true, CallData: callData}, nil
+	}
+
+	callData, err := r.aggregator.Pack("latestRoundData")
+	if err != nil {
+		return contracts.Multicall3Call3{}, fmt.Errorf("falha ao codificar latestRoundData: %w", err)
+	}
+	return contracts.Multicall3Call3{Target: handle.address, AllowFailure: true, CallData: callData}, nil
+}
+
+// latestRoundData lê latestRoundData de todos os feeds em um único eth_call
+// via aggregate3. Falhas individuais (allowFailure) são devolvidas em Err de
+// cada resultado; o erro retornado indica falha da chamada inteira.
+func (r *multicallReader) latestRoundData(callOpts *bind.CallOpts, handles []*feedHandle) ([]latestRoundResult, error) {
+	calls := make([]contracts.Multicall3Call3, len(handles))
+	for i, handle := range handles {
+		call, err := r.latestRoundCall(handle)
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = call
+	}
+
+	var out []interface{}
+	if err := r.multicall.Call(callOpts, &out, "aggregate3", calls); err != nil {
+		return nil, fmt.Errorf("%w: %v", errMulticallFailed, err)
+	}
+
+	returnData := *abi.ConvertType(out[0], new([]contracts.Multicall3Result)).(*[]contracts.Multicall3Result)
+	if len(returnData) != len(handles) {
+		return nil, fmt.Errorf("%w: %d resultados para %d chamadas", errMulticallFailed, len(returnData), len(handles))
+	}
+
+	results := make([]latestRoundResult, len(handles))
+	for i, result := range returnData {
+		address := handles[i].address
+		if !result.Success {
+			results[i].Err = upstreamError(fmt.Errorf("chamada a latestRoundData revertida em %s", address.Hex()))
+			continue
+		}
+		if err := r.aggregator.UnpackIntoInterface(&results[i], "latestRoundData", result.ReturnData); err != nil {
+			results[i].Err = upstreamError(fmt.Errorf("falha ao decodificar latestRoundData de %s: %w", address.Hex(), err))
+		}
+	}
+
+	return results, nil
+}
+
+// fetchPricesMulticall lê o preço de todos os ativos com um único eth_call.
+// As falhas de cada ativo são devolvidas em errs, na posição do ativo; err
+// indica a falha da chamada inteira.
+func (s *ChainlinkService) fetchPricesMulticall(ctx context.Context, assets []string, opts QueryOptions) (prices []*PriceData, errs []error, err error) {
+	prices = make([]*PriceData, len(assets))
+	errs = make([]error, len(assets))
+
+	var handles []*feedHandle
+	var positions []int
+	for i, asset := range assets {
+		handle, err := s.feedHandle(ctx, asset)
+		if err != nil {
+			errs[i] = fmt.Errorf("falha ao buscar preço para %s: %w", asset, err)
+			continue
+		}
+		handles = append(handles, handle)
+		positions = append(positions, i)
+	}
+	if len(handles) == 0 {
+		return prices, errs, nil
+	}
+
+	results, err := s.multicall.latestRoundData(opts.callOpts(ctx), handles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for j, result := range results {
+		i, asset := positions[j], assets[positions[j]]
+		if result.Err != nil {
+			errs[i] = fmt.Errorf("falha ao buscar preço para %s: %w", asset, result.Err)
+			continue
+		}
+
+		handle, err := s.checkPhase(ctx, asset, handles[j], result.RoundId)
+		if err != nil {
+			errs[i] = fmt.Errorf("falha ao buscar preço para %s: %w", asset, err)
+			continue
+		}
+
+		round := newRoundData(result.RoundId, result.Answer, result.StartedAt, result.UpdatedAt, result.AnsweredInRound, handle.decimals)
+		priceData, err := s.newPriceData(asset, round, opts)
+		if err != nil {
+			errs[i] = fmt.Errorf("falha ao buscar preço para %s: %w", asset, err)
+			continue
+		}
+		prices[i] = priceData
+	}
+
+	return prices, errs, nil
+}
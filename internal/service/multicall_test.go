@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// blockingCodeBackend responde a CodeAt só quando recebe o resultado em
+// results, simulando um nó lento.
+type blockingCodeBackend struct {
+	bind.ContractBackend
+	started chan struct{}
+	results chan error
+}
+
+func (b *blockingCodeBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	b.started <- struct{}{}
+	if err := <-b.results; err != nil {
+		return nil, err
+	}
+	return []byte{1}, nil
+}
+
+func TestMulticallProbeDoesNotBlock(t *testing.T) {
+	backend := &blockingCodeBackend{started: make(chan struct{}), results: make(chan error)}
+	reader, err := newMulticallReader(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probed := make(chan bool)
+	go func() { probed <- reader.isAvailable(context.Background()) }()
+	<-backend.started
+
+	done := make(chan bool)
+	go func() { done <- reader.isAvailable(context.Background()) }()
+	select {
+	case available := <-done:
+		if available {
+			t.Error("isAvailable() = true durante a verificação, esperado false")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("isAvailable() esperou pela verificação em andamento")
+	}
+
+	backend.results <- nil
+	if !<-probed {
+		t.Error("isAvailable() = false com código no endereço, esperado true")
+	}
+	if !reader.isAvailable(context.Background()) {
+		t.Error("resultado da verificação não foi guardado")
+	}
+}
+
+func TestMulticallProbeRetriesAfterFailure(t *testing.T) {
+	backend := &blockingCodeBackend{started: make(chan struct{}, 1), results: make(chan error, 1)}
+	reader, err := newMulticallReader(backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend.results <- errors.New("connection refused")
+	if reader.isAvailable(context.Background()) {
+		t.Fatal("isAvailable() = true após falha, esperado false")
+	}
+	<-backend.started
+	if reader.isAvailable(context.Background()) {
+		t.Fatal("isAvailable() = true antes de multicallProbeRetry, esperado false")
+	}
+
+	reader.nextAttempt = time.Now()
+	backend.results <- nil
+	if !reader.isAvailable(context.Background()) {
+		t.Error("isAvailable() = false na nova verificação, esperado true")
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Network reúne os serviços de leitura de uma rede. Poller é nil quando a
+// atualização em segundo plano está desativada, e Subscriber quando não há
+// websocket configurado.
+type Network struct {
+	Name       string
+	ChainID    uint64
+	Chainlink  *ChainlinkService
+	Cache      *PriceCache
+	Poller     *Poller
+	Subscriber *Subscriber
+}
+
+// SetFeeds aplica um novo conjunto de feeds à rede sem interromper o
+// atendimento.
+func (n *Network) SetFeeds(feeds map[string]config.Feed) {
+	n.Chainlink.SetFeeds(feeds)
+	n.Cache.Reset()
+	if n.Poller != nil {
+		n.Poller.Reload()
+	}
+	if n.Subscriber != nil {
+		n.Subscriber.Reload()
+	}
+}
+
+// DialNetwork conecta à RPC e confere se o chain ID informado pelo nó é o
+// esperado para a rede.
+func DialNetwork(ctx context.Context, network config.Network, rpcURL string) (*ethclient.Client, error) {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao conectar à rede %s: %w", network.Name, err)
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("falha ao buscar o chain ID da rede %s: %w", network.Name, err)
+	}
+	if !chainID.IsUint64() || chainID.Uint64() != network.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("RPC da rede %s retornou chain ID %s, esperado %d", network.Name, chainID, network.ChainID)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+const (
+	minPollInterval = 10 * time.Second
+	maxPollInterval = 2 * time.Minute
+
+	// pollsPerHeartbeat é a quantidade de consultas por heartbeat de um feed
+	// com limiar de desvio de 1%. Com o heartbeat de 1h dos feeds principais,
+	// isso dá uma consulta a cada 15s, pouco mais que o tempo de um bloco na
+	// Ethereum. O intervalo cresce com o limiar: um feed de 2% precisa de uma
+	// variação maior para publicar e é consultado com metade da frequência.
+	pollsPerHeartbeat = 240
+
+	// batchRefreshInterval é o intervalo da releitura completa do lote, em
+	// um único bloco. Entre elas, o lote recebe os preços lidos pelas
+	// consultas de cada feed e pelos eventos AnswerUpdated.
+	batchRefreshInterval = maxPollInterval
+)
+
+// SnapshotInfo descreve a origem de um preço servido a partir do snapshot
+// mantido pelo Poller.
+type SnapshotInfo struct {
+	FetchedAt time.Time
+	// Degraded indica que a última tentativa de atualização falhou e que o
+	// valor servido é o último obtido com sucesso.
+	Degraded  bool
+	LastError string
+}
+
+func (i SnapshotInfo) Age() time.Duration {
+	return time.Since(i.FetchedAt)
+}
+
+type snapshotEntry struct {
+	price *PriceData
+	info  SnapshotInfo
+}
+
+type batchSnapshotEntry struct {
+	batch *PriceBatch
+	info  SnapshotInfo
+}
+
+// snapshot é imutável depois de publicado; cada atualização gera uma cópia.
+type snapshot struct {
+	prices map[string]*snapshotEntry
+	all    *batchSnapshotEntry
+}
+
+// Poller mantém em memória o preço mais recente de cada feed, atualizado em
+// segundo plano, para que as requisições não dependam de chamadas ao nó.
+//
+// O snapshot tem duas visões. prices, com o preço de cada feed, atende
+// /api/price/:asset/:moeda, o streaming e o WebSocket. all, o lote de todos
+// os feeds, atende /api/price/all/:moeda e /api/prices; ele é relido por
+// inteiro em um único bloco a cada batchRefreshInterval e, entre as
+// releituras, recebe cada novo preço publicado em prices.
+type Poller struct {
+	chainlinkService *ChainlinkService
+	jobs             *feedJobs
+
+	mu      sync.Mutex
+	current atomic.Pointer[snapshot]
+	hub     *priceHub
+}
+
+func NewPoller(chainlinkService *ChainlinkService) *Poller {
+	p := &Poller{
+		chainlinkService: chainlinkService,
+		hub:              newPriceHub(),
+	}
+	p.jobs = newFeedJobs(func(ctx context.Context, asset string, feed config.Feed) {
+		p.poll(ctx, pollInterval(feed), func(ctx context.Context) { p.refreshFeed(ctx, asset) })
+	})
+	p.current.Store(&snapshot{prices: make(map[string]*snapshotEntry)})
+	return p
+}
+
+// pollInterval deriva o intervalo de consulta do heartbeat e do limiar de
+// desvio do feed: feeds que atualizam com mais frequência são consultados
+// mais vezes.
+func pollInterval(feed config.Feed) time.Duration {
+	if feed.Heartbeat <= 0 {
+		return maxPollInterval
+	}
+
+	deviation := feed.Deviation
+	if deviation <= 0 {
+		deviation = 1
+	}
+
+	interval := time.Duration(float64(feed.Heartbeat/pollsPerHeartbeat) * deviation)
+	return min(max(interval, minPollInterval), maxPollInterval)
+}
+
+// Run inicia a consulta periódica de todos os feeds e bloqueia até que ctx
+// seja cancelado.
+func (p *Poller) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.poll(ctx, batchRefreshInterval, p.refreshAll)
+	}()
+
+	p.jobs.start(ctx, p.chainlinkService.priceFeeds())
+	wg.Wait()
+}
+
+// Reload ajusta a consulta ao conjunto atual de feeds do ChainlinkService,
+// descartando do snapshot os ativos removidos.
+func (p *Poller) Reload() {
+	feeds := p.chainlinkService.priceFeeds()
+	p.jobs.sync(feeds)
+
+	p.update(func(next *snapshot) {
+		for asset := range next.prices {
+			if _, ok := feeds[asset]; !ok {
+				delete(next.prices, asset)
+			}
+		}
+		next.all = nil
+	})
+}
+
+func (p *Poller) poll(ctx context.Context, interval time.Duration, refresh func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		refreshCtx, cancel := context.WithTimeout(ctx, interval)
+		refresh(refreshCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Poller) refreshFeed(ctx context.Context, asset string) {
+	priceData, err := p.chainlinkService.GetPriceUSD(ctx, asset, QueryOptions{})
+	if err != nil {
+		log.Printf("falha ao atualizar o snapshot de %s: %v", asset, err)
+		p.update(func(next *snapshot) {
+			if entry, ok := next.prices[asset]; ok {
+				next.prices[asset] = &snapshotEntry{price: entry.price, info: degraded(entry.info, err)}
+			}
+		})
+		return
+	}
+
+	p.UpdatePrice(asset, priceData)
+}
+
+func (p *Poller) refreshAll(ctx context.Context) {
+	batch, err := p.chainlinkService.GetAllPricesUSD(ctx, QueryOptions{})
+	if err != nil {
+		log.Printf("falha ao atualizar o snapshot de todos os feeds: %v", err)
+		p.update(func(next *snapshot) {
+			if next.all != nil {
+				next.all = &batchSnapshotEntry{batch: next.all.batch, info: degraded(next.all.info, err)}
+			}
+		})
+		return
+	}
+	if err := batch.Err(); err != nil {
+		log.Printf("snapshot de todos os feeds atualizado com falhas: %v", err)
+	}
+
+	// Um evento recebido durante a leitura pode ser mais novo que o lote.
+	p.update(func(next *snapshot) {
+		next.all = &batchSnapshotEntry{batch: batch, info: SnapshotInfo{FetchedAt: time.Now()}}
+		for asset, entry := range next.prices {
+			next.all = next.all.withPrice(asset, entry.price)
+		}
+	})
+}
+
+func degraded(info SnapshotInfo, err error) SnapshotInfo {
+	info.Degraded = true
+	info.LastError = err.Error()
+	return info
+}
+
+// UpdatePrice publica um novo preço em USD para o ativo, desde que ele não
+// seja de um round anterior ao que já está no snapshot. Os assinantes são
+// avisados apenas quando o round muda.
+func (p *Poller) UpdatePrice(asset string, priceData *PriceData) {
+	changed := false
+	p.update(func(next *snapshot) {
+		entry, ok := next.prices[asset]
+		if ok && entry.price.Round.RoundID.Cmp(priceData.Round.RoundID) > 0 {
+			return
+		}
+		changed = !ok || entry.price.Round.RoundID.Cmp(priceData.Round.RoundID) != 0
+		next.prices[asset] = &snapshotEntry{price: priceData, info: SnapshotInfo{FetchedAt: time.Now()}}
+		if next.all != nil {
+			next.all = next.all.withPrice(asset, priceData)
+		}
+	})
+
+	if changed {
+		p.hub.publish(asset)
+	}
+}
+
+// withPrice retorna o lote com o preço do ativo, quando ele é de um round mais
+// novo que o do lote. Um ativo que havia falhado sai de Errors. O lote
+// alterado deixa de ser a leitura de um único bloco e fica sem Block.
+func (e *batchSnapshotEntry) withPrice(asset string, priceData *PriceData) *batchSnapshotEntry {
+	batch := *e.batch
+	if i := slices.Index(batch.Assets, asset); i >= 0 {
+		if batch.Prices[i].Round.RoundID.Cmp(priceData.Round.RoundID) >= 0 {
+			return e
+		}
+		batch.Prices = slices.Clone(batch.Prices)
+		batch.Prices[i] = priceData
+	} else {
+		batch.Errors = slices.DeleteFunc(slices.Clone(batch.Errors), func(assetErr *AssetError) bool {
+			return assetErr.Asset == asset
+		})
+		i, _ := slices.BinarySearch(batch.Assets, asset)
+		batch.Assets = slices.Insert(slices.Clone(batch.Assets), i, asset)
+		batch.Prices = slices.Insert(slices.Clone(batch.Prices), i, priceData)
+	}
+	batch.Block = nil
+	return &batchSnapshotEntry{batch: &batch, info: e.info}
+}
+
+// Subscribe registra um assinante das mudanças de preço do snapshot. A
+// assinatura deve ser encerrada com Close.
+func (p *Poller) Subscribe() *PriceSubscription {
+	return p.hub.subscribe()
+}
+
+func (p *Poller) update(apply func(next *snapshot)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := p.current.Load()
+	next := &snapshot{prices: maps.Clone(current.prices), all: current.all}
+	apply(next)
+	p.current.Store(next)
+}
+
+// snapshotPrice copia o preço do snapshot e refaz a validação no instante de
+// referência, já que a idade do preço aumenta enquanto ele fica em memória.
+func (p *Poller) snapshotPrice(asset string, priceData *PriceData, reference time.Time, mode ValidationMode) (*PriceData, error) {
+	clone := *priceData
+	if err := p.chainlinkService.applyValidation(asset, &clone, reference, mode); err != nil {
+		return nil, err
+	}
+	return &clone, nil
+}
+
+// PriceUSD retorna o preço do snapshot. ok é false quando o ativo ainda não
+// foi carregado, e a requisição deve seguir pelo caminho normal.
+func (p *Poller) PriceUSD(asset string, mode ValidationMode) (priceData *PriceData, ok bool, err error) {
+	entry, found := p.current.Load().prices[asset]
+	if !found {
+		return nil, false, nil
+	}
+
+	priceData, err = p.snapshotPrice(asset, entry.price, time.Now(), mode)
+	if err != nil {
+		return nil, true, err
+	}
+	info := entry.info
+	priceData.Snapshot = &info
+	return priceData, true, nil
+}
+
+func (p *Poller) PriceFiat(ctx context.Context, asset, currency string, mode ValidationMode) (*PriceData, bool, error) {
+	priceData, ok, err := p.PriceUSD(asset, mode)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	rate, err := p.chainlinkService.fiatRate(ctx, currency)
+	if err != nil {
+		return nil, true, err
+	}
+	return convertToCurrency(asset, priceData, currency, rate), true, nil
+}
+
+func (p *Poller) AllPricesUSD(mode ValidationMode) (*PriceBatch, bool, error) {
+	return p.pricesUSD(nil, mode)
+}
+
+func (p *Poller) AllPricesFiat(ctx context.Context, currency string, mode ValidationMode) (*PriceBatch, bool, error) {
+	return p.toFiat(ctx, currency)(p.AllPricesUSD(mode))
+}
+
+// PricesUSD retorna apenas os ativos informados do lote do snapshot. ok é
+// false quando algum deles ainda não foi carregado.
+func (p *Poller) PricesUSD(assets []string, mode ValidationMode) (*PriceBatch, bool, error) {
+	return p.pricesUSD(assets, mode)
+}
+
+func (p *Poller) PricesFiat(ctx context.Context, assets []string, currency string, mode ValidationMode) (*PriceBatch, bool, error) {
+	return p.toFiat(ctx, currency)(p.PricesUSD(assets, mode))
+}
+
+// pricesUSD monta o lote a partir do snapshot de todos os feeds. Com assets
+// nil, todos os ativos do snapshot são incluídos.
+func (p *Poller) pricesUSD(assets []string, mode ValidationMode) (*PriceBatch, bool, error) {
+	entry := p.current.Load().all
+	if entry == nil {
+		return nil, false, nil
+	}
+
+	include := func(string) bool { return true }
+	if assets != nil {
+		loaded := slices.Clone(entry.batch.Assets)
+		for _, assetErr := range entry.batch.Errors {
+			loaded = append(loaded, assetErr.Asset)
+		}
+		for _, asset := range assets {
+			if !slices.Contains(loaded, asset) {
+				return nil, false, nil
+			}
+		}
+		include = func(asset string) bool { return slices.Contains(assets, asset) }
+	}
+
+	// A idade é calculada em relação ao momento atual, e não ao bloco do
+	// lote, já que o snapshot é servido como o preço mais recente. Um ativo
+	// que não passa na validação vai para a lista de falhas.
+	reference := time.Now()
+	info := entry.info
+	batch := &PriceBatch{Block: entry.batch.Block, Snapshot: &info}
+	for _, assetErr := range entry.batch.Errors {
+		if include(assetErr.Asset) {
+			batch.Errors = append(batch.Errors, assetErr)
+		}
+	}
+	for i, priceData := range entry.batch.Prices {
+		asset := entry.batch.Assets[i]
+		if !include(asset) {
+			continue
+		}
+		validated, err := p.snapshotPrice(asset, priceData, reference, mode)
+		if err != nil {
+			batch.Errors = append(batch.Errors, &AssetError{Asset: asset, Err: err})
+			continue
+		}
+		batch.Assets = append(batch.Assets, asset)
+		batch.Prices = append(batch.Prices, validated)
+	}
+
+	if len(batch.Prices) == 0 && len(batch.Errors) > 0 {
+		return nil, true, batch.Err()
+	}
+	return batch, true, nil
+}
+
+// toFiat converte um lote do snapshot para a moeda informada.
+func (p *Poller) toFiat(ctx context.Context, currency string) func(batch *PriceBatch, ok bool, err error) (*PriceBatch, bool, error) {
+	return func(batch *PriceBatch, ok bool, err error) (*PriceBatch, bool, error) {
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+
+		rate, err := p.chainlinkService.fiatRate(ctx, currency)
+		if err != nil {
+			return nil, true, err
+		}
+
+		for i, priceData := range batch.Prices {
+			batch.Prices[i] = convertToCurrency(batch.Assets[i], priceData, currency, rate)
+		}
+		return batch, true, nil
+	}
+}
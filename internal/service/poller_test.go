@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+func testPrice(roundID int64) *PriceData {
+	return &PriceData{Round: &RoundData{RoundID: big.NewInt(roundID)}}
+}
+
+func TestBatchSnapshotEntryWithPrice(t *testing.T) {
+	block := &BlockRef{Number: big.NewInt(100)}
+	entry := &batchSnapshotEntry{batch: &PriceBatch{
+		Block:  block,
+		Assets: []string{"btc", "link"},
+		Prices: []*PriceData{testPrice(10), testPrice(20)},
+		Errors: []*AssetError{{Asset: "eth", Err: errors.New("falha")}},
+	}}
+
+	tests := []struct {
+		name       string
+		asset      string
+		price      *PriceData
+		wantAssets []string
+		wantErrors int
+		wantBlock  bool
+	}{
+		{"round mais novo", "btc", testPrice(11), []string{"btc", "link"}, 1, false},
+		{"mesmo round", "btc", testPrice(10), []string{"btc", "link"}, 1, true},
+		{"round anterior", "link", testPrice(19), []string{"btc", "link"}, 1, true},
+		{"ativo que havia falhado", "eth", testPrice(5), []string{"btc", "eth", "link"}, 0, false},
+		{"ativo fora do lote", "uni", testPrice(1), []string{"btc", "link", "uni"}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entry.withPrice(tt.asset, tt.price).batch
+			if !slices.Equal(got.Assets, tt.wantAssets) {
+				t.Errorf("Assets = %v, esperado %v", got.Assets, tt.wantAssets)
+			}
+			if len(got.Errors) != tt.wantErrors {
+				t.Errorf("len(Errors) = %d, esperado %d", len(got.Errors), tt.wantErrors)
+			}
+			if (got.Block != nil) != tt.wantBlock {
+				t.Errorf("Block = %v, esperado presente = %v", got.Block, tt.wantBlock)
+			}
+			if i := slices.Index(got.Assets, tt.asset); !tt.wantBlock && got.Prices[i] != tt.price {
+				t.Errorf("preço de %s não foi substituído", tt.asset)
+			}
+		})
+	}
+
+	if len(entry.batch.Assets) != 2 || entry.batch.Prices[0].Round.RoundID.Int64() != 10 || len(entry.batch.Errors) != 1 || entry.batch.Block == nil {
+		t.Error("o lote original foi alterado")
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	tests := []struct {
+		name string
+		feed config.Feed
+		want time.Duration
+	}{
+		{"heartbeat de 1h e desvio de 1%", config.Feed{Heartbeat: time.Hour, Deviation: 1}, 15 * time.Second},
+		{"desvio de 2% dobra o intervalo", config.Feed{Heartbeat: time.Hour, Deviation: 2}, 30 * time.Second},
+		{"limitado ao mínimo", config.Feed{Heartbeat: time.Hour, Deviation: 0.5}, minPollInterval},
+		{"limitado ao máximo", config.Feed{Heartbeat: 24 * time.Hour, Deviation: 1}, maxPollInterval},
+		{"sem desvio usa 1%", config.Feed{Heartbeat: time.Hour}, 15 * time.Second},
+		{"sem heartbeat", config.Feed{}, maxPollInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pollInterval(tt.feed); got != tt.want {
+				t.Errorf("pollInterval() = %v, esperado %v", got, tt.want)
+			}
+		})
+	}
+}
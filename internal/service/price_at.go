@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// GetPriceAt retorna o round que estava em vigor no instante informado, ou
+// seja, o último round cujo updatedAt é menor ou igual a at. Dentro de cada
+// fase os timestamps são crescentes, o que permite uma busca binária.
+func (s *ChainlinkService) GetPriceAt(ctx context.Context, asset string, at time.Time, opts QueryOptions) (*PriceData, error) {
+	if err := s.checkUSDQuote(asset); err != nil {
+		return nil, err
+	}
+
+	handle, err := s.feedHandle(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireProxy(asset, handle); err != nil {
+		return nil, err
+	}
+
+	priceFeed, decimals := handle.contract, handle.decimals
+	callOpts := opts.callOpts(ctx)
+
+	latestRoundData, err := priceFeed.LatestRoundData(callOpts)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar dados para %s: %w", asset, err))
+	}
+	if handle, err = s.checkPhase(ctx, asset, handle, latestRoundData.RoundId); err != nil {
+		return nil, err
+	}
+	priceFeed, decimals = handle.contract, handle.decimals
+
+	target := at.Unix()
+	roundID := latestRoundData.RoundId
+	phases := newPhaseCache(priceFeed, s.client, callOpts)
+
+	if latestRoundData.UpdatedAt.Int64() > target {
+		roundID, err = searchRoundAt(priceFeed, phases, callOpts, latestRoundData.RoundId, target)
+		if err != nil {
+			return nil, upstreamError(fmt.Errorf("falha ao buscar round de %s em %s: %w", asset, at.UTC().Format(time.RFC3339), err))
+		}
+	}
+
+	round, err := priceFeed.GetRoundData(callOpts, roundID)
+	if err != nil {
+		return nil, upstreamError(fmt.Errorf("falha ao buscar round %s para %s: %w", roundID, asset, err))
+	}
+
+	roundData := newRoundData(round.RoundId, round.Answer, round.StartedAt, round.UpdatedAt, round.AnsweredInRound, decimals)
+	if phase, err := phases.get(roundData.PhaseID); err == nil {
+		roundData.Aggregator = phase.Aggregator
+	}
+
+	feed, _ := s.feed(asset)
+	priceData := &PriceData{
+		Pair:      s.pairName(asset),
+		Currency:  strings.ToUpper(feed.Quote),
+		Price:     roundData.Price,
+		Timestamp: roundData.UpdatedAt,
+		Round:     roundData,
+		Block:     opts.Block,
+	}
+
+	if err := s.applyValidation(asset, priceData, at, opts.Validation); err != nil {
+		return nil, err
+	}
+
+	return priceData, nil
+}
+
+// GetPriceFiatAt é como GetPriceAt, com o preço convertido pela taxa de
+// câmbio em vigor no updatedAt do round encontrado.
+func (s *ChainlinkService) GetPriceFiatAt(ctx context.Context, asset, currency string, at time.Time, opts QueryOptions) (*PriceData, error) {
+	if err := s.CheckCurrency(ctx, currency); err != nil {
+		return nil, err
+	}
+
+	priceData, err := s.GetPriceAt(ctx, asset, at, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.fiatRateAt(ctx, currency, priceData.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return convertToCurrency(asset, priceData, currency, rate), nil
+}
+
+func searchRoundAt(priceFeed *contracts.AggregatorV3Interface, phases *phaseCache, callOpts *bind.CallOpts, latestRoundID *big.Int, target int64) (*big.Int, error) {
+	timestampOf := func(phaseID uint16, aggregatorRoundID uint64) (int64, error) {
+		timestamp, err := priceFeed.GetTimestamp(callOpts, contracts.EncodeRoundID(phaseID, aggregatorRoundID))
+		if err != nil {
+			return 0, err
+		}
+		return timestamp.Int64(), nil
+	}
+
+	latestPhaseID, latestAggregatorRoundID := contracts.DecodeRoundID(latestRoundID)
+
+	for phaseID := latestPhaseID; phaseID >= 1; phaseID-- {
+		lastRound := latestAggregatorRoundID
+		if phaseID != latestPhaseID {
+			phase, err := phases.get(phaseID)
+			if err != nil {
+				return nil, err
+			}
+			lastRound = phase.LatestRoundID
+		}
+		if lastRound == 0 {
+			continue
+		}
+
+		firstTimestamp, err := timestampOf(phaseID, 1)
+		if err != nil {
+			return nil, err
+		}
+		if firstTimestamp == 0 || firstTimestamp > target {
+			continue
+		}
+
+		// Rounds sem timestamp (incompletos) são tratados como posteriores ao
+		// alvo, para que a busca nunca os selecione.
+		var searchErr error
+		offset := sort.Search(int(lastRound), func(i int) bool {
+			if searchErr != nil {
+				return true
+			}
+			timestamp, err := timestampOf(phaseID, uint64(i)+1)
+			if err != nil {
+				searchErr = err
+				return true
+			}
+			return timestamp == 0 || timestamp > target
+		})
+		if searchErr != nil {
+			return nil, searchErr
+		}
+
+		return contracts.EncodeRoundID(phaseID, uint64(offset)), nil
+	}
+
+	return nil, classify(ErrNotFound, errors.New("nenhum round disponível antes do instante informado"))
+}
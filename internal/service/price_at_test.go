@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func TestGetPriceAtCurrency(t *testing.T) {
+	// Os agregadores das fases são lidos pelo cliente RPC, aqui inalcançável:
+	// o preço é retornado sem o endereço do agregador.
+	client, err := ethclient.Dial("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	s := newTestChainlinkService(map[string]config.Feed{"btc": {Quote: "usd"}})
+	s.client = client
+	s.feedCache = newFeedCache()
+
+	backend := &fakeFeedBackend{phases: map[uint16]uint64{1: 3}}
+	feed, err := contracts.NewAggregatorV3Interface(testProxy, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.feedCache.handles["btc"] = &feedHandle{address: testProxy, contract: feed, decimals: 8, phaseID: 1}
+
+	priceData, err := s.GetPriceAt(context.Background(), "btc", time.Now(), QueryOptions{})
+	if err != nil {
+		t.Fatalf("GetPriceAt() erro: %v", err)
+	}
+	if priceData.Currency != "USD" {
+		t.Errorf("Currency = %q, esperado %q", priceData.Currency, "USD")
+	}
+	if _, aggregatorRoundID := contracts.DecodeRoundID(priceData.Round.RoundID); aggregatorRoundID != 3 {
+		t.Errorf("round = %d, esperado o último round, 3", aggregatorRoundID)
+	}
+}
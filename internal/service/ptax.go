@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ptaxAPIURL = "https://olinda.bcb.gov.br/olinda/servico/PTAX/versao/v1/odata"
+	// ptaxLookbackDays cobre fins de semana e feriados, em que não há
+	// boletim.
+	ptaxLookbackDays = 7
+	// ptaxFirstBulletinHour é o horário do primeiro boletim do dia.
+	ptaxFirstBulletinHour = 10
+)
+
+// brasiliaTime é o fuso dos boletins da PTAX, sem horário de verão desde
+// 2019.
+var brasiliaTime = time.FixedZone("BRT", -3*60*60)
+
+type ptaxQuote struct {
+	CotacaoVenda    json.Number `json:"cotacaoVenda"`
+	DataHoraCotacao string      `json:"dataHoraCotacao"`
+	TipoBoletim     string      `json:"tipoBoletim"`
+}
+
+func (q ptaxQuote) date() string {
+	date, _, _ := strings.Cut(q.DataHoraCotacao, " ")
+	return date
+}
+
+// time retorna o horário do boletim, como "2024-01-05 13:09:28.383".
+func (q ptaxQuote) time() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05.999", q.DataHoraCotacao, brasiliaTime)
+}
+
+type ptaxCurrency struct {
+	Simbolo       string `json:"simbolo"`
+	NomeFormatado string `json:"nomeFormatado"`
+}
+
+// PTAXProvider usa a PTAX do Banco Central do Brasil, que cota cada moeda em
+// BRL. Os demais pares são calculados pelo BRL.
+type PTAXProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewPTAXProvider(baseURL string) *PTAXProvider {
+	if baseURL == "" {
+		baseURL = ptaxAPIURL
+	}
+	return &PTAXProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (p *PTAXProvider) Name() string {
+	return "ptax"
+}
+
+func (p *PTAXProvider) GetRate(ctx context.Context, from, to string) (*FXRate, error) {
+	return crossFXRate(from, to, p.Name(), func(currency string) (*fxQuote, error) {
+		return p.brlValue(ctx, currency, time.Time{})
+	})
+}
+
+// GetRateAt usa o último boletim publicado até at.
+func (p *PTAXProvider) GetRateAt(ctx context.Context, from, to string, at time.Time) (*FXRate, error) {
+	return crossFXRate(from, to, p.Name(), func(currency string) (*fxQuote, error) {
+		return p.brlValue(ctx, currency, at)
+	})
+}
+
+func (p *PTAXProvider) Currencies(ctx context.Context) (map[string]string, error) {
+	var result struct {
+		Value []ptaxCurrency `json:"value"`
+	}
+	if err := getJSON(ctx, p.httpClient, p.baseURL+"/Moedas?$format=json", &result); err != nil {
+		return nil, fmt.Errorf("falha ao buscar moedas da PTAX: %w", err)
+	}
+
+	currencies := map[string]string{"BRL": "Real brasileiro"}
+	for _, currency := range result.Value {
+		currencies[currency.Simbolo] = currency.NomeFormatado
+	}
+	return currencies, nil
+}
+
+// brlValue retorna a PTAX de venda da moeda publicada até at, ou a mais
+// recente quando at é zero, preferindo o boletim de fechamento do dia.
+// Depois do fechamento, a cotação só muda no primeiro boletim do próximo dia
+// útil.
+func (p *PTAXProvider) brlValue(ctx context.Context, currency string, at time.Time) (*fxQuote, error) {
+	if currency == "BRL" {
+		return &fxQuote{value: big.NewRat(1, 1)}, nil
+	}
+
+	latestOnly := at.IsZero()
+	if latestOnly {
+		at = time.Now()
+	}
+	end := at.In(brasiliaTime)
+	query := fmt.Sprintf("@moeda=%s&@dataInicial=%s&@dataFinalCotacao=%s&$format=json",
+		url.QueryEscape("'"+currency+"'"),
+		url.QueryEscape(end.AddDate(0, 0, -ptaxLookbackDays).Format("'01-02-2006'")),
+		url.QueryEscape(end.Format("'01-02-2006'")),
+	)
+
+	var result struct {
+		Value []ptaxQuote `json:"value"`
+	}
+	endpoint := p.baseURL + "/CotacaoMoedaPeriodo(moeda=@moeda,dataInicial=@dataInicial,dataFinalCotacao=@dataFinalCotacao)?" + query
+	if err := getJSON(ctx, p.httpClient, endpoint, &result); err != nil {
+		return nil, fmt.Errorf("falha ao buscar a PTAX de %s: %w", currency, err)
+	}
+	if !latestOnly {
+		result.Value = slices.DeleteFunc(result.Value, func(quote ptaxQuote) bool {
+			published, err := quote.time()
+			return err != nil || published.After(at)
+		})
+	}
+	if len(result.Value) == 0 {
+		return nil, fmt.Errorf("%w: sem PTAX para %s", ErrUnsupportedCurrency, currency)
+	}
+
+	latest := result.Value[len(result.Value)-1]
+	for _, quote := range result.Value {
+		if quote.TipoBoletim == "Fechamento" && quote.date() == latest.date() {
+			latest = quote
+		}
+	}
+	value, ok := new(big.Rat).SetString(latest.CotacaoVenda.String())
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("PTAX inválida para %s", currency)
+	}
+
+	quote := &fxQuote{value: value}
+	if date, err := time.ParseInLocation(time.DateOnly, latest.date(), brasiliaTime); err == nil {
+		quote.date = date
+	}
+	if latestOnly && latest.TipoBoletim == "Fechamento" {
+		quote.nextUpdate = nextWeekdayAt(time.Now(), ptaxFirstBulletinHour, 0, brasiliaTime)
+	}
+	return quote, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Phase struct {
+	ID            uint16
+	Aggregator    common.Address
+	LatestRoundID uint64
+}
+
+// RoundIterator percorre os rounds de um feed do mais recente para o mais
+// antigo, atravessando as trocas de agregador (fases) do proxy.
+type RoundIterator struct {
+	Round *RoundData
+
+	feed     *contracts.AggregatorV3Interface
+	callOpts *bind.CallOpts
+	decimals uint8
+
+	phaseID           uint16
+	aggregatorRoundID uint64
+	phases            *phaseCache
+
+	done bool
+	err  error
+}
+
+func newRoundIterator(feed *contracts.AggregatorV3Interface, backend bind.ContractBackend, callOpts *bind.CallOpts, decimals uint8, start *big.Int) *RoundIterator {
+	phaseID, aggregatorRoundID := contracts.DecodeRoundID(start)
+	return &RoundIterator{
+		feed:              feed,
+		callOpts:          callOpts,
+		decimals:          decimals,
+		phaseID:           phaseID,
+		aggregatorRoundID: aggregatorRoundID,
+		phases:            newPhaseCache(feed, backend, callOpts),
+	}
+}
+
+// Next avança para o round anterior. Retorna false quando não há mais
+// rounds ou quando ocorre um erro, que pode ser consultado em Error.
+func (it *RoundIterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if it.aggregatorRoundID == 0 && !it.previousPhase() {
+		return false
+	}
+
+	roundID := contracts.EncodeRoundID(it.phaseID, it.aggregatorRoundID)
+	round, err := it.feed.GetRoundData(it.callOpts, roundID)
+	if err != nil {
+		it.err = fmt.Errorf("falha ao buscar round %s: %w", roundID, err)
+		return false
+	}
+
+	phase, err := it.phases.get(it.phaseID)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.Round = newRoundData(round.RoundId, round.Answer, round.StartedAt, round.UpdatedAt, round.AnsweredInRound, it.decimals)
+	it.Round.Aggregator = phase.Aggregator
+	it.aggregatorRoundID--
+	return true
+}
+
+func (it *RoundIterator) Error() error {
+	return it.err
+}
+
+// Cursor retorna o roundId do próximo round que seria lido, ou nil quando o
+// iterador chegou ao primeiro round da primeira fase.
+func (it *RoundIterator) Cursor() *big.Int {
+	if it.done {
+		return nil
+	}
+	if it.aggregatorRoundID == 0 {
+		if it.err != nil || !it.previousPhase() {
+			return nil
+		}
+	}
+	return contracts.EncodeRoundID(it.phaseID, it.aggregatorRoundID)
+}
+
+// Phase retorna o agregador e o último round da fase informada.
+func (it *RoundIterator) Phase(phaseID uint16) (*Phase, error) {
+	return it.phases.get(phaseID)
+}
+
+func (it *RoundIterator) previousPhase() bool {
+	for it.phaseID > 1 {
+		it.phaseID--
+		phase, err := it.phases.get(it.phaseID)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if phase.LatestRoundID > 0 {
+			it.aggregatorRoundID = phase.LatestRoundID
+			return true
+		}
+	}
+	it.done = true
+	return false
+}
+
+// phaseCache guarda o agregador e o último round de cada fase do proxy,
+// consultando o contrato apenas na primeira vez em que a fase é acessada.
+type phaseCache struct {
+	feed     *contracts.AggregatorV3Interface
+	backend  bind.ContractBackend
+	callOpts *bind.CallOpts
+	phases   map[uint16]*Phase
+}
+
+func newPhaseCache(feed *contracts.AggregatorV3Interface, backend bind.ContractBackend, callOpts *bind.CallOpts) *phaseCache {
+	return &phaseCache{
+		feed:     feed,
+		backend:  backend,
+		callOpts: callOpts,
+		phases:   make(map[uint16]*Phase),
+	}
+}
+
+func (c *phaseCache) get(phaseID uint16) (*Phase, error) {
+	if phase, ok := c.phases[phaseID]; ok {
+		return phase, nil
+	}
+
+	aggregator, err := c.feed.PhaseAggregators(c.callOpts, phaseID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar agregador da fase %d: %w", phaseID, err)
+	}
+
+	phase := &Phase{ID: phaseID, Aggregator: aggregator}
+	if aggregator != (common.Address{}) {
+		aggregatorCaller, err := contracts.NewAggregatorV3InterfaceCaller(aggregator, c.backend)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao instanciar agregador da fase %d: %w", phaseID, err)
+		}
+		latestRound, err := aggregatorCaller.LatestRound(c.callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao buscar último round da fase %d: %w", phaseID, err)
+		}
+		phase.LatestRoundID = latestRound.Uint64()
+	}
+
+	c.phases[phaseID] = phase
+	return phase, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testProxy = common.HexToAddress("0x0000000000000000000000000000000000000100")
+
+// fakeFeedBackend simula um proxy da Chainlink e os agregadores de cada
+// fase. phases associa cada fase ao último round do seu agregador; fases
+// com zero não têm agregador. As demais chamadas ao backend não são
+// implementadas.
+type fakeFeedBackend struct {
+	bind.ContractBackend
+	phases map[uint16]uint64
+}
+
+func phaseAggregator(phaseID uint16) common.Address {
+	return common.BigToAddress(big.NewInt(int64(0x1000 + phaseID)))
+}
+
+func (b *fakeFeedBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (b *fakeFeedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	parsed, err := contracts.AggregatorV3InterfaceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case *call.To == testProxy && method.Name == "phaseAggregators":
+		phaseID := args[0].(uint16)
+		if b.phases[phaseID] == 0 {
+			return method.Outputs.Pack(common.Address{})
+		}
+		return method.Outputs.Pack(phaseAggregator(phaseID))
+
+	case *call.To == testProxy && method.Name == "getRoundData":
+		roundID := args[0].(*big.Int)
+		phaseID, aggregatorRoundID := contracts.DecodeRoundID(roundID)
+		if aggregatorRoundID == 0 || aggregatorRoundID > b.phases[phaseID] {
+			return nil, fmt.Errorf("round %s inexistente", roundID)
+		}
+		updatedAt := big.NewInt(int64(phaseID)*1_000_000 + int64(aggregatorRoundID))
+		return method.Outputs.Pack(roundID, big.NewInt(int64(aggregatorRoundID)*100), updatedAt, updatedAt, roundID)
+
+	case *call.To == testProxy && method.Name == "latestRoundData":
+		var phaseID uint16
+		for id, latest := range b.phases {
+			if latest > 0 && id > phaseID {
+				phaseID = id
+			}
+		}
+		roundID := contracts.EncodeRoundID(phaseID, b.phases[phaseID])
+		updatedAt := big.NewInt(int64(phaseID)*1_000_000 + int64(b.phases[phaseID]))
+		return method.Outputs.Pack(roundID, big.NewInt(int64(b.phases[phaseID])*100), updatedAt, updatedAt, roundID)
+
+	case method.Name == "latestRound":
+		for phaseID, latest := range b.phases {
+			if *call.To == phaseAggregator(phaseID) {
+				return method.Outputs.Pack(new(big.Int).SetUint64(latest))
+			}
+		}
+	}
+	return nil, fmt.Errorf("chamada %s a %s não simulada", method.Name, call.To.Hex())
+}
+
+func newTestRoundIterator(t *testing.T, phases map[uint16]uint64, start *big.Int) *RoundIterator {
+	t.Helper()
+	backend := &fakeFeedBackend{phases: phases}
+	feed, err := contracts.NewAggregatorV3Interface(testProxy, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newRoundIterator(feed, backend, &bind.CallOpts{}, 8, start)
+}
+
+type roundRef struct {
+	phaseID           uint16
+	aggregatorRoundID uint64
+}
+
+func TestRoundIterator(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases map[uint16]uint64
+		start  *big.Int
+		want   []roundRef
+	}{
+		{
+			name:   "atravessa a troca de fase",
+			phases: map[uint16]uint64{1: 2, 2: 2},
+			start:  contracts.EncodeRoundID(2, 2),
+			want:   []roundRef{{2, 2}, {2, 1}, {1, 2}, {1, 1}},
+		},
+		{
+			name:   "pula fases sem rounds",
+			phases: map[uint16]uint64{1: 1, 3: 2},
+			start:  contracts.EncodeRoundID(3, 2),
+			want:   []roundRef{{3, 2}, {3, 1}, {1, 1}},
+		},
+		{
+			name:   "início no round 0 de uma fase parte da anterior",
+			phases: map[uint16]uint64{1: 2, 2: 5},
+			start:  contracts.EncodeRoundID(2, 0),
+			want:   []roundRef{{1, 2}, {1, 1}},
+		},
+		{
+			name:   "fase 0 termina no round 1",
+			phases: map[uint16]uint64{0: 3},
+			start:  big.NewInt(3),
+			want:   []roundRef{{0, 3}, {0, 2}, {0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := newTestRoundIterator(t, tt.phases, tt.start)
+
+			var got []roundRef
+			for it.Next() {
+				phaseID, aggregatorRoundID := contracts.DecodeRoundID(it.Round.RoundID)
+				got = append(got, roundRef{phaseID, aggregatorRoundID})
+				if it.Round.Aggregator != phaseAggregator(phaseID) {
+					t.Errorf("round %d/%d com agregador %s, esperado %s", phaseID, aggregatorRoundID, it.Round.Aggregator.Hex(), phaseAggregator(phaseID).Hex())
+				}
+			}
+			if err := it.Error(); err != nil {
+				t.Fatalf("Error() = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("rounds = %v, esperado %v", got, tt.want)
+			}
+			if cursor := it.Cursor(); cursor != nil {
+				t.Errorf("Cursor() = %s ao final, esperado nil", cursor)
+			}
+		})
+	}
+}
+
+func TestRoundIteratorCursor(t *testing.T) {
+	phases := map[uint16]uint64{1: 4, 2: 0, 3: 2}
+
+	tests := []struct {
+		name  string
+		reads int
+		want  *big.Int
+	}{
+		{"antes da primeira leitura", 0, contracts.EncodeRoundID(3, 2)},
+		{"no meio da fase", 1, contracts.EncodeRoundID(3, 1)},
+		{"após o round 1 da fase, pula a fase vazia", 2, contracts.EncodeRoundID(1, 4)},
+		{"após o round 1 da primeira fase", 6, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := newTestRoundIterator(t, phases, contracts.EncodeRoundID(3, 2))
+			for range tt.reads {
+				if !it.Next() {
+					t.Fatalf("Next() = false, erro: %v", it.Error())
+				}
+			}
+
+			cursor := it.Cursor()
+			if (cursor == nil) != (tt.want == nil) || (cursor != nil && cursor.Cmp(tt.want) != 0) {
+				t.Errorf("Cursor() = %v, esperado %v", cursor, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundIteratorResumesFromCursor(t *testing.T) {
+	phases := map[uint16]uint64{1: 2, 2: 0, 3: 1}
+
+	it := newTestRoundIterator(t, phases, contracts.EncodeRoundID(3, 1))
+	if !it.Next() {
+		t.Fatalf("Next() = false, erro: %v", it.Error())
+	}
+	cursor := it.Cursor()
+
+	resumed := newTestRoundIterator(t, phases, cursor)
+	if !resumed.Next() {
+		t.Fatalf("Next() = false, erro: %v", resumed.Error())
+	}
+	if phaseID, aggregatorRoundID := contracts.DecodeRoundID(resumed.Round.RoundID); phaseID != 1 || aggregatorRoundID != 2 {
+		t.Errorf("round retomado = %d/%d, esperado 1/2", phaseID, aggregatorRoundID)
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxSelectedAssets limita a quantidade de ativos informados em uma seleção.
+const MaxSelectedAssets = 50
+
+type PriceSort string
+
+const (
+	SortBySymbol PriceSort = "symbol"
+	SortByPrice  PriceSort = "price"
+	SortByAge    PriceSort = "age"
+)
+
+// PriceSelection define quais preços de um lote são retornados e em que
+// ordem. Assets vazio seleciona todos os ativos da rede; MaxAgeSeconds
+// positivo descarta os preços mais antigos que ele.
+type PriceSelection struct {
+	Assets        []string
+	Category      string
+	MaxAgeSeconds int64
+	Sort          PriceSort
+	Descending    bool
+}
+
+// SelectAssets resolve os ativos a serem lidos: os informados, validados e
+// sem repetição, ou todos os da rede, restritos à categoria quando ela é
+// informada. Os ativos são retornados em ordem alfabética, para que a mesma
+// seleção em outra ordem use a mesma entrada do cache de lotes.
+func (s *ChainlinkService) SelectAssets(selection PriceSelection) ([]string, error) {
+	if len(selection.Assets) > MaxSelectedAssets {
+		return nil, classify(ErrInvalidArgument, fmt.Errorf("no máximo %d ativos podem ser informados", MaxSelectedAssets))
+	}
+
+	candidates := s.Assets()
+	if len(selection.Assets) > 0 {
+		candidates = nil
+		for _, asset := range selection.Assets {
+			if err := s.checkUSDQuote(asset); err != nil {
+				return nil, err
+			}
+			if !slices.Contains(candidates, asset) {
+				candidates = append(candidates, asset)
+			}
+		}
+		slices.Sort(candidates)
+	}
+	if selection.Category == "" {
+		return candidates, nil
+	}
+
+	if !slices.Contains(s.Categories(), selection.Category) {
+		return nil, classify(ErrInvalidArgument, fmt.Errorf("categoria '%s' não encontrada", selection.Category))
+	}
+	assets := make([]string, 0, len(candidates))
+	for _, asset := range candidates {
+		if feed, _ := s.feed(asset); feed.Category == selection.Category {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+// Categories retorna, em ordem alfabética, as categorias dos ativos da rede.
+func (s *ChainlinkService) Categories() []string {
+	var categories []string
+	for _, feed := range s.priceFeeds() {
+		if feed.Category != "" && !slices.Contains(categories, feed.Category) {
+			categories = append(categories, feed.Category)
+		}
+	}
+	slices.Sort(categories)
+	return categories
+}
+
+// Apply filtra o lote pela idade e o ordena. Empates são desfeitos pelo
+// símbolo, para que a ordem seja sempre a mesma.
+func (selection PriceSelection) Apply(batch *PriceBatch) *PriceBatch {
+	selected := *batch
+	selected.Assets = nil
+	selected.Prices = nil
+	for i, priceData := range batch.Prices {
+		if selection.MaxAgeSeconds > 0 && priceData.AgeSeconds > selection.MaxAgeSeconds {
+			continue
+		}
+		selected.Assets = append(selected.Assets, batch.Assets[i])
+		selected.Prices = append(selected.Prices, priceData)
+	}
+
+	order := make([]int, len(selected.Prices))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		var result int
+		switch selection.Sort {
+		case SortByPrice:
+			result = selected.Prices[a].Price.Cmp(selected.Prices[b].Price)
+		case SortByAge:
+			result = cmp.Compare(selected.Prices[a].AgeSeconds, selected.Prices[b].AgeSeconds)
+		}
+		if selection.Descending {
+			result = -result
+		}
+		if result == 0 {
+			result = strings.Compare(selected.Assets[a], selected.Assets[b])
+			if selection.Sort == SortBySymbol && selection.Descending {
+				result = -result
+			}
+		}
+		return result
+	})
+
+	assets := make([]string, len(order))
+	prices := make([]*PriceData, len(order))
+	for i, index := range order {
+		assets[i], prices[i] = selected.Assets[index], selected.Prices[index]
+	}
+	selected.Assets, selected.Prices = assets, prices
+	return &selected
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/contracts"
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	resubscribeDelay    = 5 * time.Second
+	maxResubscribeDelay = 2 * time.Minute
+	// aggregatorCheckInterval é o intervalo em que o proxy é consultado para
+	// detectar a troca do agregador, que deixa de emitir eventos.
+	aggregatorCheckInterval = 10 * time.Minute
+	// maxBackfillBlocks limita a janela de eventos buscados após uma
+	// reconexão, já que os provedores restringem o intervalo de eth_getLogs.
+	maxBackfillBlocks = 5000
+)
+
+// Subscriber assina os eventos AnswerUpdated dos agregadores por trás de cada
+// proxy, por uma conexão websocket, e publica cada nova resposta no snapshot
+// do Poller assim que ela é emitida.
+type Subscriber struct {
+	client           *ethclient.Client
+	chainlinkService *ChainlinkService
+	poller           *Poller
+	jobs             *feedJobs
+}
+
+func NewSubscriber(client *ethclient.Client, chainlinkService *ChainlinkService, poller *Poller) *Subscriber {
+	s := &Subscriber{
+		client:           client,
+		chainlinkService: chainlinkService,
+		poller:           poller,
+	}
+	s.jobs = newFeedJobs(func(ctx context.Context, asset string, _ config.Feed) {
+		s.watchFeed(ctx, asset)
+	})
+	return s
+}
+
+// Run mantém as assinaturas de todos os feeds e bloqueia até que ctx seja
+// cancelado.
+func (s *Subscriber) Run(ctx context.Context) {
+	s.jobs.start(ctx, s.chainlinkService.priceFeeds())
+}
+
+// Reload ajusta as assinaturas ao conjunto atual de feeds do
+// ChainlinkService.
+func (s *Subscriber) Reload() {
+	s.jobs.sync(s.chainlinkService.priceFeeds())
+}
+
+// aggregatorSubscription é a assinatura ativa de um feed, presa a uma fase.
+type aggregatorSubscription struct {
+	asset      string
+	phaseID    uint16
+	aggregator common.Address
+	decimals   uint8
+	filterer   *contracts.AggregatorV3InterfaceFilterer
+}
+
+// watchFeed assina o feed e, sempre que a assinatura cai ou o agregador
+// muda, assina novamente buscando os eventos perdidos desde o último bloco
+// processado.
+func (s *Subscriber) watchFeed(ctx context.Context, asset string) {
+	var lastBlock uint64
+	delay := resubscribeDelay
+
+	for {
+		err := s.subscribe(ctx, asset, &lastBlock)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			delay = resubscribeDelay
+			continue
+		}
+
+		log.Printf("assinatura de eventos de %s encerrada, nova tentativa em %s: %v", asset, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxResubscribeDelay)
+	}
+}
+
+// subscribe retorna nil quando a assinatura deve ser refeita imediatamente,
+// como na troca de agregador, e um erro quando ela falhou.
+func (s *Subscriber) subscribe(ctx context.Context, asset string, lastBlock *uint64) error {
+	sub, err := s.resolveAggregator(ctx, asset)
+	if err != nil {
+		return err
+	}
+
+	sink := make(chan *contracts.AggregatorV3InterfaceAnswerUpdated)
+	subscription, err := sub.filterer.WatchAnswerUpdated(&bind.WatchOpts{Context: ctx}, sink, nil, nil)
+	if err != nil {
+		return fmt.Errorf("falha ao assinar AnswerUpdated: %w", err)
+	}
+	defer subscription.Unsubscribe()
+
+	log.Printf("assinando eventos de %s no agregador %s (fase %d)", asset, sub.aggregator.Hex(), sub.phaseID)
+
+	// O backfill é feito depois de a assinatura estar ativa para que nenhum
+	// evento fique entre as duas etapas; eventos repetidos são ignorados
+	// pelo Poller.
+	if *lastBlock > 0 {
+		if err := s.backfill(ctx, sub, lastBlock); err != nil {
+			log.Printf("falha ao buscar eventos perdidos de %s: %v", asset, err)
+		}
+	}
+
+	check := time.NewTicker(aggregatorCheckInterval)
+	defer check.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-subscription.Err():
+			return err
+		case event := <-sink:
+			s.publish(sub, event, lastBlock)
+		case <-check.C:
+			current, err := s.resolveAggregator(ctx, asset)
+			if err != nil {
+				log.Printf("falha ao verificar o agregador de %s: %v", asset, err)
+				continue
+			}
+			if current.aggregator != sub.aggregator {
+				log.Printf("agregador de %s mudou para %s, assinando novamente", asset, current.aggregator.Hex())
+				return nil
+			}
+		}
+	}
+}
+
+// resolveAggregator lê do proxy a fase atual e o agregador correspondente,
+// atualizando os metadados do feed se a fase mudou.
+func (s *Subscriber) resolveAggregator(ctx context.Context, asset string) (*aggregatorSubscription, error) {
+	handle, err := s.chainlinkService.feedHandle(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	phaseID, err := handle.contract.PhaseId(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar fase para %s: %w", asset, err)
+	}
+
+	handle, err = s.chainlinkService.checkPhase(ctx, asset, handle, contracts.EncodeRoundID(phaseID, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	aggregator, err := handle.contract.PhaseAggregators(callOpts, phaseID)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao buscar agregador da fase %d para %s: %w", phaseID, asset, err)
+	}
+
+	filterer, err := contracts.NewAggregatorV3InterfaceFilterer(aggregator, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao instanciar agregador para %s: %w", asset, err)
+	}
+
+	return &aggregatorSubscription{
+		asset:      asset,
+		phaseID:    phaseID,
+		aggregator: aggregator,
+		decimals:   handle.decimals,
+		filterer:   filterer,
+	}, nil
+}
+
+func (s *Subscriber) backfill(ctx context.Context, sub *aggregatorSubscription, lastBlock *uint64) error {
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("falha ao buscar o bloco atual: %w", err)
+	}
+
+	start := *lastBlock + 1
+	if head > maxBackfillBlocks && start < head-maxBackfillBlocks {
+		start = head - maxBackfillBlocks
+	}
+	if start > head {
+		return nil
+	}
+
+	events, err := sub.filterer.FilterAnswerUpdated(&bind.FilterOpts{Start: start, End: &head, Context: ctx}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("falha ao buscar AnswerUpdated: %w", err)
+	}
+	defer events.Close()
+
+	for events.Next() {
+		s.publish(sub, events.Event, lastBlock)
+	}
+	return events.Error()
+}
+
+// publish converte o evento em um round do proxy. Nos agregadores OCR o
+// round é iniciado e respondido na mesma transação, então startedAt e
+// answeredInRound coincidem com updatedAt e com o próprio round.
+func (s *Subscriber) publish(sub *aggregatorSubscription, event *contracts.AggregatorV3InterfaceAnswerUpdated, lastBlock *uint64) {
+	if event.Raw.Removed {
+		return
+	}
+	*lastBlock = max(*lastBlock, event.Raw.BlockNumber)
+
+	roundID := contracts.EncodeRoundID(sub.phaseID, event.RoundId.Uint64())
+	round := newRoundData(roundID, event.Current, event.UpdatedAt, event.UpdatedAt, new(big.Int).Set(roundID), sub.decimals)
+	round.Aggregator = sub.aggregator
+
+	priceData, err := s.chainlinkService.newUSDPriceData(sub.asset, round, QueryOptions{})
+	if err != nil {
+		log.Printf("evento de %s descartado: %v", sub.asset, err)
+		return
+	}
+
+	s.poller.UpdatePrice(sub.asset, priceData)
+}
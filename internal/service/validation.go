@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+var (
+	ErrStalePrice      = errors.New("preço desatualizado")
+	ErrInvalidAnswer   = errors.New("resposta inválida")
+	ErrIncompleteRound = errors.New("round incompleto")
+)
+
+type ValidationMode int
+
+const (
+	// ValidationLenient retorna o preço mesmo quando a validação falha,
+	// sinalizando o problema em PriceData.
+	ValidationLenient ValidationMode = iota
+	// ValidationStrict transforma qualquer falha de validação em erro.
+	ValidationStrict
+)
+
+type FeedValidationError struct {
+	Asset  string
+	Reason error
+	Detail string
+}
+
+func (e *FeedValidationError) Error() string {
+	return fmt.Sprintf("feed de %s: %v: %s", e.Asset, e.Reason, e.Detail)
+}
+
+func (e *FeedValidationError) Unwrap() error {
+	return e.Reason
+}
+
+// validateRound verifica a resposta de um round em relação ao instante de
+// referência, que é o timestamp do bloco quando a leitura está fixada.
+func validateRound(asset string, feed config.Feed, round *RoundData, reference time.Time) (ageSeconds int64, err error) {
+	ageSeconds = reference.Unix() - round.UpdatedAt
+
+	switch {
+	case round.Answer.Sign() <= 0:
+		return ageSeconds, &FeedValidationError{Asset: asset, Reason: ErrInvalidAnswer, Detail: fmt.Sprintf("answer %s menor ou igual a zero", round.Answer)}
+	case round.UpdatedAt == 0:
+		return ageSeconds, &FeedValidationError{Asset: asset, Reason: ErrIncompleteRound, Detail: fmt.Sprintf("round %s sem timestamp de atualização", round.RoundID)}
+	case round.AnsweredInRound.Cmp(round.RoundID) < 0:
+		return ageSeconds, &FeedValidationError{Asset: asset, Reason: ErrIncompleteRound, Detail: fmt.Sprintf("answeredInRound %s anterior ao round %s", round.AnsweredInRound, round.RoundID)}
+	case feed.Heartbeat > 0 && ageSeconds > int64(feed.Heartbeat/time.Second):
+		return ageSeconds, &FeedValidationError{Asset: asset, Reason: ErrStalePrice, Detail: fmt.Sprintf("última atualização há %ds, heartbeat de %s", ageSeconds, feed.Heartbeat)}
+	}
+
+	return ageSeconds, nil
+}
+
+// applyValidation valida o round de priceData e, conforme o modo, retorna o
+// erro ou apenas marca o preço como desatualizado.
+func (s *ChainlinkService) applyValidation(asset string, priceData *PriceData, reference time.Time, mode ValidationMode) error {
+	feed, _ := s.feed(asset)
+	ageSeconds, err := validateRound(asset, feed, priceData.Round, reference)
+	priceData.AgeSeconds = ageSeconds
+	priceData.Stale = false
+	priceData.Warning = ""
+	if err == nil {
+		return nil
+	}
+	if mode == ValidationStrict {
+		return err
+	}
+
+	priceData.Stale = true
+	priceData.Warning = err.Error()
+	return nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/dev-araujo/chainlink-price-feed/internal/config"
+)
+
+func TestValidateRound(t *testing.T) {
+	reference := time.Unix(10_000, 0)
+	round := func(answer, updatedAt, roundID, answeredInRound int64) *RoundData {
+		return newRoundData(big.NewInt(roundID), big.NewInt(answer), big.NewInt(updatedAt), big.NewInt(updatedAt), big.NewInt(answeredInRound), 8)
+	}
+	feed := config.Feed{Quote: "usd", Heartbeat: time.Hour}
+
+	tests := []struct {
+		name       string
+		round      *RoundData
+		wantErr    error
+		wantDetail string
+	}{
+		{"válido", round(100, 9_000, 5, 5), nil, ""},
+		{"answer zero", round(0, 9_000, 5, 5), ErrInvalidAnswer, "answer 0 menor ou igual a zero"},
+		{"round sem timestamp", round(100, 0, 5, 5), ErrIncompleteRound, "round 5 sem timestamp de atualização"},
+		{"answeredInRound anterior", round(100, 9_000, 5, 4), ErrIncompleteRound, "answeredInRound 4 anterior ao round 5"},
+		{"acima do heartbeat", round(100, 6_000, 5, 5), ErrStalePrice, "última atualização há 4000s, heartbeat de 1h0m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateRound("btc", feed, tt.round, reference)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("erro inesperado: %v", err)
+				}
+				return
+			}
+			var validationErr *FeedValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("erro = %v, esperado %v", err, tt.wantErr)
+			}
+			if validationErr.Detail != tt.wantDetail {
+				t.Errorf("Detail = %q, esperado %q", validationErr.Detail, tt.wantDetail)
+			}
+		})
+	}
+}